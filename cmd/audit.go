@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Zubimendi/erc20gen/internal/audit"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Grade a token config against a best-practices matrix without generating code",
+	Long: `Audit takes the same token flags (or --config file) as generate and
+prints a finding for every risky or centralizing choice, most severe first.
+
+Example:
+  erc20gen audit --config token.yaml
+  erc20gen audit --name MyToken --symbol MTK --mintable --pausable --json`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	addTokenFlags(auditCmd.Flags())
+	auditCmd.Flags().Bool("json", false, "Print findings as a JSON array")
+}
+
+var severityIcons = map[audit.Severity]string{
+	audit.SeverityHigh:   "🔴",
+	audit.SeverityMedium: "🟠",
+	audit.SeverityLow:    "🟡",
+	audit.SeverityInfo:   "🔵",
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	cfg, err := resolveTokenConfig(cmd)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	findings := audit.Run(cfg)
+
+	out := cmd.OutOrStdout()
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		if findings == nil {
+			findings = []audit.Finding{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintf(out, "✅ %s: no findings\n", cfg.Name)
+		return nil
+	}
+	fmt.Fprintf(out, "🔎 %s: %d finding(s)\n", cfg.Name, len(findings))
+	for _, f := range findings {
+		fmt.Fprintf(out, "  %s %-6s %s\n", severityIcons[f.Severity], strings.ToUpper(string(f.Severity)), f.Message)
+	}
+	return nil
+}
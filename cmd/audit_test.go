@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeAudit runs `erc20gen audit` with the given args and returns stdout.
+func executeAudit(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	resetFlags(auditCmd)
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs(append([]string{"audit"}, args...))
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestAudit_PrintsFindings(t *testing.T) {
+	out, err := executeAudit(t, "--name", "AuditToken", "--symbol", "AUD", "--mintable", "--pausable")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Mintable without a cap")
+	assert.Contains(t, out, "Pausable adds centralization")
+}
+
+func TestAudit_JSON(t *testing.T) {
+	out, err := executeAudit(t, "--name", "AuditToken", "--symbol", "AUD", "--mintable", "--max-supply", "1000", "--json")
+	require.NoError(t, err)
+
+	var findings []audit.Finding
+	require.NoError(t, json.Unmarshal([]byte(out), &findings))
+	for _, f := range findings {
+		assert.NotEqual(t, "uncapped-mint", f.Rule)
+	}
+}
+
+func TestAudit_RejectsInvalidConfig(t *testing.T) {
+	_, err := executeAudit(t, "--name", "AuditToken", "--symbol", "lo-wer")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var capabilitiesJSON bool
+
+// capabilityMatrix is the `capabilities --json` document.
+type capabilityMatrix struct {
+	Contracts    []contractCapability `json:"contracts"`
+	Features     []config.Capability  `json:"features"`
+	Rules        []config.FeatureRule `json:"rules"`
+	Deprecations []config.Deprecation `json:"deprecations"`
+}
+
+type contractCapability struct {
+	Name    string `json:"name"`
+	Flag    string `json:"flag,omitempty"`
+	Summary string `json:"summary"`
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "Print the feature compatibility matrix: which features require or conflict with which",
+	Long: `Capabilities prints the rules generate enforces between features — requires,
+conflicts, and features implied per OpenZeppelin version — plus the contracts
+list-features shows and the deprecation matrix --strict checks. With --json the
+same data is printed as one JSON document, for UIs built on top of erc20gen.
+
+Example:
+  erc20gen capabilities --json > capabilities.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if capabilitiesJSON {
+			m := capabilityMatrix{
+				Features:     config.Capabilities,
+				Rules:        config.FeatureRules,
+				Deprecations: config.DeprecationMatrix(),
+			}
+			for _, name := range config.FeatureNames() {
+				d := config.FeatureDescriptors[name]
+				m.Contracts = append(m.Contracts, contractCapability{Name: name, Flag: d.Flag, Summary: d.Summary})
+			}
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false)
+			return enc.Encode(m)
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FEATURE\tRULE\tOTHER\tOZ")
+		for _, r := range config.FeatureRules {
+			oz := r.OZVersion
+			if oz == "" {
+				oz = "all"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Feature, r.Kind, r.Other, oz)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	capabilitiesCmd.Flags().BoolVar(&capabilitiesJSON, "json", false, "print the matrix as JSON")
+	rootCmd.AddCommand(capabilitiesCmd)
+}
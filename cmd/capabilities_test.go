@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities_JSONIncludesRules(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		capabilitiesJSON = false
+	})
+	rootCmd.SetArgs([]string{"capabilities", "--json"})
+	require.NoError(t, rootCmd.Execute())
+
+	var m struct {
+		Contracts []struct{ Name, Flag string }
+		Features  []struct{ Name, Flag string }
+		Rules     []config.FeatureRule
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &m))
+
+	assert.Contains(t, m.Rules, config.FeatureRule{Feature: "Votes", Kind: config.RuleImplies, Other: "Snapshot", OZVersion: config.OZv4})
+	assert.Contains(t, m.Rules, config.FeatureRule{
+		Feature: "BurnRebase", Kind: config.RuleConflicts, Other: "Capped", Field: "BurnRebase",
+		Message: "a burn rebase conflicts with --max-supply — ERC20Capped would check the cap against stored shares, not the rebased supply",
+	})
+
+	var names []string
+	for _, c := range m.Contracts {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, config.FeatureNames(), names, "contracts mirror list-features")
+	assert.Contains(t, m.Features, struct{ Name, Flag string }{"Capped", "--max-supply"})
+}
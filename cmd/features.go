@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var listFeaturesCmd = &cobra.Command{
+	Use:   "list-features",
+	Short: "List the OpenZeppelin contracts erc20gen can inherit and the flags that enable them",
+	Run: func(cmd *cobra.Command, args []string) {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CONTRACT\tFLAG\tDESCRIPTION")
+		for _, name := range config.FeatureNames() {
+			d := config.FeatureDescriptors[name]
+			flag := d.Flag
+			if flag == "" {
+				flag = "(always)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", name, flag, d.Summary)
+		}
+		_ = w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listFeaturesCmd)
+}
@@ -1,14 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/spf13/cobra"
 	"github.com/Zubimendi/erc20gen/internal/config"
-	"github.com/Zubimendi/erc20gen/internal/generator"
 	"github.com/Zubimendi/erc20gen/internal/prompts"
+	"github.com/Zubimendi/erc20gen/internal/solc"
+	"github.com/Zubimendi/erc20gen/pkg/erc20gen"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 var generateCmd = &cobra.Command{
@@ -21,6 +29,9 @@ Examples:
   # Interactive mode (recommended)
   erc20gen generate
 
+  # Flags for everything but the symbol, which is prompted for
+  erc20gen generate --name "MyToken" --mintable
+
   # Non-interactive with flags
   erc20gen generate \
     --name "MyToken" \
@@ -30,10 +41,27 @@ Examples:
     --mintable \
     --burnable \
     --pausable \
-    --out ./contracts
+    --layout hardhat \
+    --out .
 
   # From a config file
-  erc20gen generate --config token.yaml`,
+  erc20gen generate --config token.yaml
+
+  # From an OpenZeppelin Wizard JSON export
+  erc20gen generate --from-wizard-json wizard.json --with-test
+
+  # From environment variables (ERC20GEN_ + flag name, dashes → underscores)
+  ERC20GEN_DECIMALS=6 erc20gen generate --name "MyToken" --symbol "MTK"
+
+  # From a curated preset (explicit flags still override it)
+  erc20gen generate --name "USD Coin" --symbol "USDC" --preset stablecoin
+
+Presets: stablecoin (6 decimals, mintable, pausable, roles), governance
+(votes, permit, snapshot, ownable), meme (fixed 1B supply, burnable, no
+owner), utility (mintable, burnable, ownable), immutable (fixed supply, no
+owner; rejects conflicting flags).
+
+Precedence: flag > environment variable > config file > preset > default.`,
 	RunE: runGenerate,
 }
 
@@ -41,147 +69,736 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 
 	f := generateCmd.Flags()
+	addTokenFlags(f)
+	f.String("out", ".", "Project root directory for generated files")
+	f.String("network", "", "Deploy script target: mainnet | sepolia | polygon | arbitrum | custom (default: network-agnostic)")
+	f.String("out-zip", "", "Write all generated files into this zip archive instead of --out")
+	f.String("layout", "hardhat", "Output layout under --out: hardhat | foundry | flat")
+	f.String("file-mode", "0640", "Permissions for generated files (octal)")
+	f.String("dir-mode", "0750", "Permissions for created directories (octal)")
+	f.Bool("with-deploy", false, "Also generate a Hardhat deployment script")
+	f.String("deploy-style", "ethers", "Deploy script flavor: ethers (scripts/, hardhat run) | hardhat-deploy (deploy/, hardhat-deploy plugin)")
+	f.Bool("with-test", false, "Also generate a Hardhat test file skeleton")
+	f.String("test-style", "ethers-js", "Test skeleton flavor: ethers-js (.test.js) | viem-ts (.test.ts)")
+	f.Bool("with-abi", false, "Also generate a <Name>.abi.json for frontend integration")
+	f.String("post-hook", "", "Command run on each generated file after writing, e.g. \"npx prettier --write\" (no shell; the path is appended)")
+	f.String("diff-against", "", "Generate in memory and print a unified diff against a previous generation in this directory, without writing (pair with the same --seed)")
+	f.Bool("show-diff", false, "Print a unified diff of each existing file before overwriting it")
+	f.Bool("check", false, "Generate in memory and diff against the files already at the target paths; fail on any difference (for CI, requires --seed)")
+	f.String("record", "", "Append a CSV row (timestamp, name, symbol, decimals, features, access, config hash) to this file after generating")
+	f.Bool("compile", false, "Compile the written contract with solc (or solcjs) and report errors; skipped if neither is installed")
+	f.Bool("with-readme", false, "Also generate a project README.md with compile, test, and deploy commands")
+	f.Bool("with-ci", false, "Also generate a GitHub Actions workflow (.github/workflows/contracts.yml) that compiles and tests the project")
+	f.Bool("with-typechain", false, "Also generate typechain.config.js, a hardhat.config.js fragment enabling TypeChain ethers-v6 bindings")
+	f.Bool("with-env", false, "Also generate a .env.example with placeholders for the deployer key, RPC URL, and explorer API key")
+	f.Bool("output-single-file", false, "Write one .sol with the contract and a commented-out deploy snippet (for Remix)")
+	f.Bool("provenance", false, "Record erc20gen version, features, and a config SHA-256 in the contract header")
+	f.Bool("optimize", false, "Wrap provably overflow-free arithmetic in unchecked blocks (saves gas, less readable)")
+	f.Bool("extract-libraries", false, "Move helper math (mint schedule) into linked Solidity libraries")
+	f.Bool("minify", false, "Strip comments and blank lines from the contract (keeps SPDX and pragma)")
+	f.String("style", "standard", "Contract style: standard | minimal (no comments) | verbose (adds --explain notes and a privileges summary)")
+	f.Bool("group-imports", false, "Group the contract's imports into commented blocks (core, extensions, access control, ...)")
+	f.String("indent", "", "Indentation of generated code: tabs | a space count from 1 to 8 (default: 4 spaces in Solidity, 2 in JS/TS)")
+	f.String("eol", "lf", "Line endings of generated files: lf | crlf")
+	f.Bool("strict", false, "Fail instead of warning when the contract would use OpenZeppelin patterns deprecated in --oz-version")
+	f.Bool("allow-unlimited-mint", false, "Accept a mintable token without --max-supply without a warning, confirmation, or --strict error")
+	f.Bool("explain", false, "Annotate the contract with comments explaining each inherited contract and override")
+	f.Bool("interactive", true, "Use interactive prompts (disable with --interactive=false)")
+	f.Int64("seed", 0, "Seed for placeholder values; a fixed seed makes output byte-identical across runs (default: time-based)")
+}
+
+// addTokenFlags registers the flags that describe the token itself, shared
+// by every command that builds a TokenConfig (generate, audit).
+func addTokenFlags(f *pflag.FlagSet) {
 	f.String("name", "", "Token name (e.g. MyToken)")
+	f.String("display-name", "", "On-chain name() if it differs from --name, e.g. with non-ASCII characters (\"Café Coin\")")
 	f.String("symbol", "", "Token symbol (e.g. MTK)")
-	f.Uint8("decimals", 18, "Number of decimals (0-18)")
+	f.String("symbol-case", "auto-upper", "Symbol normalization: auto-upper (uppercase before validation) | strict (reject lowercase)")
+	f.Uint8("decimals", 18, "Number of decimals (0-77; above 18 prints a compatibility warning)")
 	f.String("initial-supply", "", "Initial supply (in whole tokens, e.g. 1000000)")
+	f.Bool("no-initial-supply", false, "Mint nothing at deployment, overriding --initial-supply, presets, and config files")
 	f.String("max-supply", "", "Maximum supply cap (leave empty for unlimited)")
+	f.Bool("fixed-supply", false, "Cap supply at the initial supply (ERC20Capped, no minting)")
+	f.Bool("emit-cap-reached", false, "Emit CapReached() from the mint that fills --max-supply (requires --mintable)")
+	f.String("initial-holder", "", "Address that receives the initial supply (default: deployer/admin)")
+	f.StringArray("allocation", nil, "Genesis allocation \"<address>=<whole tokens>\" (repeatable; amounts must sum to --initial-supply)")
 	f.Bool("mintable", false, "Allow minting new tokens after deployment")
+	f.Bool("with-airdrop", false, "Add batchTransfer(address[],uint256[]) for airdrops from the caller's balance")
+	f.Bool("airdrop-restricted", false, "Limit batchTransfer to the owner (or DEFAULT_ADMIN_ROLE)")
+	f.Bool("with-merkle-claim", false, "Add claim(uint256,bytes32[]) minting allowlisted amounts proven against --merkle-root, once per account")
+	f.Bool("with-metadata", false, "Add an ERC-7572 contractURI() getter with an owner/admin setContractURI(string)")
+	f.String("metadata-uri", "", "Initial contractURI(): an http://, https://, or ipfs:// URI (requires --with-metadata)")
+	f.Bool("with-burn-rebase", false, "Add an owner/admin applyBurnRebase(uint256 bps) that burns the same share of every balance (negative rebase)")
+	f.Int("burn-rebase-max-bps", config.DefaultBurnRebaseMaxBps, "Largest applyBurnRebase() call in basis points (1-9999)")
+	f.String("merkle-root", "", "0x-prefixed 32-byte merkle root of the (account, amount) claim tree (requires --with-merkle-claim)")
+	f.String("bridge", "", "Bridge address allowed to mint(address,uint256) and burn(address,uint256) (burn-and-mint bridges)")
+	f.String("mint-schedule", "none", "Limit mint() issuance over time: none | linear (requires --mintable)")
+	f.String("emission-rate", "", "Linear schedule: whole tokens that become mintable per second")
+	f.Int64("emission-start", 0, "Linear schedule: unix timestamp emission starts accruing from")
 	f.Bool("burnable", false, "Allow token holders to burn their tokens")
+	f.Bool("admin-burn", false, "Add an access-controlled burnFrom that needs no allowance")
+	f.Bool("reject-self-transfer", false, "Revert transfers and mints to the token contract's own address")
+	f.Bool("reentrancy-guard", false, "Inherit ReentrancyGuard and mark mint, burnFrom, bridge mint/burn and claim nonReentrant")
+	f.Bool("with-locks", false, "Add an admin lock(address,uint256,uint64) that freezes part of a balance until a release time")
 	f.Bool("pausable", false, "Allow owner to pause all token transfers")
+	f.Bool("start-paused", false, "Deploy with transfers paused (requires --pausable)")
 	f.Bool("permit", false, "Add EIP-2612 permit() for gasless approvals")
 	f.Bool("snapshot", false, "Add snapshot capability for governance")
+	f.Bool("snapshot-on-deploy", false, "Take snapshot 1 in the constructor to record genesis balances, e.g. for airdrops (requires --snapshot)")
 	f.Bool("votes", false, "Add ERC-20 Votes for on-chain governance")
+	f.String("clock-mode", "blocknumber", "Votes checkpoint clock: blocknumber | timestamp (timestamp requires --votes)")
+	f.Bool("with-governor", false, "Also generate a companion OpenZeppelin Governor for the token (requires --votes)")
+	f.Int64("voting-delay", config.DefaultVotingDelay, "Governor: delay before voting starts, in clock units (blocks, or seconds with --clock-mode timestamp)")
+	f.Int64("voting-period", config.DefaultVotingPeriod, "Governor: length of the voting window, in clock units")
+	f.Uint8("quorum-percent", config.DefaultQuorumPercent, "Governor: quorum as a percentage of total supply (1-100)")
 	f.String("access", "ownable", "Access control: ownable | roles | none")
+	f.String("admin-address", "", "Roles mode: address granted DEFAULT_ADMIN_ROLE and every feature role (default: deployer)")
+	f.String("upgradeable", "none", "Proxy pattern: none | uups | transparent")
 	f.String("license", "MIT", "SPDX license identifier")
+	f.String("title", "", "NatSpec @title above the contract (default: token name)")
+	f.String("author", "", "NatSpec @author above the contract")
+	f.String("notice", "", "NatSpec @notice above the contract")
+	f.String("oz-version", "5", "OpenZeppelin Contracts major version: 4 | 5")
 	f.String("solidity-version", "^0.8.24", "Solidity compiler version pragma")
-	f.String("out", "./contracts", "Output directory for generated files")
-	f.Bool("with-deploy", false, "Also generate a Hardhat deployment script")
-	f.Bool("with-test", false, "Also generate a Hardhat test file skeleton")
-	f.Bool("interactive", true, "Use interactive prompts (disable with --interactive=false)")
+	f.String("evm-version", "", "solc evmVersion to compile for: london, paris, shanghai, cancun, or prague; writes compiler.config.js (and foundry.toml in the foundry layout)")
+	f.StringArray("ctor-param", nil, "Extra constructor parameter \"<type> <name>\" stored in an immutable (repeatable)")
+	f.Bool("expose-params", false, "Also store the initial supply and cap in public immutables (initialSupply, maxSupply)")
+	f.StringArray("extra-import", nil, "Advanced: extra Solidity import path, e.g. a custom extension (repeatable)")
+	f.StringArray("extra-parent", nil, "Advanced: extra parent contract to inherit; its required overrides are up to you (repeatable)")
+	f.Bool("check-ticker", false, "Warn when --symbol matches a well-known token's ticker (USDC, DAI, WETH, ...)")
+	f.Bool("custom-errors", false, "Revert with custom errors instead of require() reason strings (default: on for --oz-version 5, off for 4)")
+	f.String("guard-style", "", "How checks revert: require | if-revert (default: require with reason strings, if-revert with custom errors)")
+	f.Bool("with-events", true, "Declare and emit events for admin actions OpenZeppelin does not log (admin burn, balance lock, scheduled mint)")
+	f.String("preset", "", "Start from a preset: stablecoin | governance | meme | utility | immutable")
+	f.String("from-wizard-json", "", "Import token options from an OpenZeppelin Wizard JSON export")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
-	var cfg *config.TokenConfig
-	var err error
+	cfg, err := resolveTokenConfig(cmd)
+	if err != nil {
+		return err
+	}
 
-	interactive, _ := cmd.Flags().GetBool("interactive")
-	nameFlag, _ := cmd.Flags().GetString("name")
+	if strict, _ := cmd.Flags().GetBool("strict"); strict {
+		cfg.Strict = true
+	}
+	if explain, _ := cmd.Flags().GetBool("explain"); explain {
+		cfg.Explain = true
+	}
+	if minify, _ := cmd.Flags().GetBool("minify"); minify {
+		cfg.Minify = true
+	}
+	style, _ := cmd.Flags().GetString("style")
+	cfg.Style = config.ContractStyle(style)
+	if group, _ := cmd.Flags().GetBool("group-imports"); group {
+		cfg.GroupImports = true
+	}
+	cfg.Indent, _ = cmd.Flags().GetString("indent")
+	eol, _ := cmd.Flags().GetString("eol")
+	cfg.EOL = config.LineEnding(eol)
+	if optimize, _ := cmd.Flags().GetBool("optimize"); optimize {
+		cfg.Optimize = true
+	}
+	if extract, _ := cmd.Flags().GetBool("extract-libraries"); extract {
+		cfg.ExtractLibraries = true
+	}
+	if provenance, _ := cmd.Flags().GetBool("provenance"); provenance {
+		cfg.Provenance = true
+	}
 
-	// If no name flag is provided and interactive mode is on, use prompts
-	if interactive && nameFlag == "" {
-		cfg, err = prompts.CollectTokenConfig()
+	// Validate
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	logger.Debug("resolved config",
+		"name", cfg.Name, "symbol", cfg.Symbol, "decimals", cfg.Decimals,
+		"access", cfg.AccessControl, "upgradeable", cfg.Upgradeable, "oz", cfg.OZVersion,
+		"features", strings.Join(cfg.EnabledFeatures(), ","))
+	logger.Debug("resolved inheritance", "contracts", strings.Join(cfg.InheritanceList(), ", "))
+	for _, msg := range cfg.Warnings() {
+		logger.Warn("⚠️  " + msg)
+	}
+	for _, msg := range cfg.Deprecations() {
+		logger.Warn(fmt.Sprintf("⚠️  deprecated: %s (--strict makes this an error)", msg))
+	}
+	if err := confirmUnlimitedMint(cmd, cfg); err != nil {
+		return err
+	}
+
+	fileModeStr, _ := cmd.Flags().GetString("file-mode")
+	fileMode, err := parseFileMode(fileModeStr)
+	if err != nil {
+		return fmt.Errorf("--file-mode: %w", err)
+	}
+	dirModeStr, _ := cmd.Flags().GetString("dir-mode")
+	dirMode, err := parseFileMode(dirModeStr)
+	if err != nil {
+		return fmt.Errorf("--dir-mode: %w", err)
+	}
+
+	// Generate
+	outDir, _ := cmd.Flags().GetString("out")
+	outZip, _ := cmd.Flags().GetString("out-zip")
+	layout, _ := cmd.Flags().GetString("layout")
+	hook, _ := cmd.Flags().GetString("post-hook")
+	check, _ := cmd.Flags().GetBool("check")
+	diffDir, _ := cmd.Flags().GetString("diff-against")
+	showDiff, _ := cmd.Flags().GetBool("show-diff")
+	if showDiff {
+		switch {
+		case check, diffDir != "":
+			return fmt.Errorf("--show-diff prints diffs while overwriting — --check and --diff-against already print them without writing")
+		case outZip != "":
+			return fmt.Errorf("--show-diff compares files on disk and cannot be combined with --out-zip")
+		}
+	}
+	if diffDir != "" {
+		switch {
+		case check:
+			return fmt.Errorf("--diff-against cannot be combined with --check")
+		case outZip != "":
+			return fmt.Errorf("--diff-against compares files on disk and cannot be combined with --out-zip")
+		case hook != "":
+			return fmt.Errorf("--diff-against writes nothing, so --post-hook has no files to run on")
+		}
+	}
+	if check {
+		switch {
+		case outZip != "":
+			return fmt.Errorf("--check compares files on disk and cannot be combined with --out-zip")
+		case hook != "":
+			return fmt.Errorf("--check cannot be combined with --post-hook — the hook's changes to committed files would always differ")
+		case !cmd.Flags().Changed("seed"):
+			return fmt.Errorf("--check requires --seed — placeholder values must match the ones in the committed files")
+		}
+	}
+	var out artifactWriter = dirWriter{fileMode: fileMode, dirMode: dirMode}
+	if check {
+		out = &checkWriter{}
+	}
+	var diff *diffWriter
+	if diffDir != "" {
+		outDir = diffDir
+		diff = &diffWriter{w: cmd.OutOrStdout()}
+		out = diff
+	}
+	if showDiff {
+		out = &showDiffWriter{artifactWriter: out, w: cmd.OutOrStdout()}
+	}
+	if outZip != "" {
+		// Entries keep the layout's relative structure inside the archive.
+		outDir = ""
+		zw, err := newZipWriter(outZip, fileMode, dirMode)
 		if err != nil {
-			return fmt.Errorf("prompt error: %w", err)
+			return fmt.Errorf("failed to create zip archive: %w", err)
 		}
-	} else {
-		// Build config from flags
-		cfg, err = buildConfigFromFlags(cmd)
+		out = zw
+	}
+	if hook != "" {
+		if outZip != "" {
+			_ = out.Close()
+			return fmt.Errorf("--post-hook cannot be combined with --out-zip — archive entries are not files on disk")
+		}
+		hw, err := newHookWriter(cmd.Context(), out, hook)
 		if err != nil {
 			return err
 		}
+		out = hw
+	}
+	paths, err := resolvePaths(cfg, layout, outDir)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := writeArtifacts(cmd, cfg, paths, out); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if check {
+		if err := out.Close(); err != nil {
+			return err
+		}
+		logger.Info("✅ Generated files match the committed files under " + outDir)
+		return nil
+	}
+	if diff != nil {
+		if diff.changed == 0 {
+			logger.Info("✅ No changes against " + diffDir)
+		} else {
+			logger.Info(fmt.Sprintf("%d file(s) changed against %s (nothing written)", diff.changed, diffDir))
+		}
+		return nil
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to finalize output: %w", err)
+	}
+	if record, _ := cmd.Flags().GetString("record"); record != "" {
+		if err := appendRecord(record, cfg, time.Now(), fileMode); err != nil {
+			return fmt.Errorf("--record: %w", err)
+		}
+		logger.Info("🗂️  Recorded in " + record)
+	}
+	if outZip != "" {
+		logger.Info("📦 Archive written: " + outZip)
 	}
 
-	// Validate
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("validation error: %w", err)
+	// Optional compile check
+	if compile, _ := cmd.Flags().GetBool("compile"); compile {
+		if err := compileContract(cmd.Context(), paths.Contract, outZip != ""); err != nil {
+			return err
+		}
 	}
 
-	// Generate
-	outDir, _ := cmd.Flags().GetString("out")
-	if err := os.MkdirAll(outDir, 0750); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	printSecurityChecklist(cfg)
+	return nil
+}
+
+// confirmUnlimitedMint gates a mintable token without a supply cap: strict
+// mode rejects it, the interactive flow asks for confirmation, and flag-driven
+// runs warn on stderr. --allow-unlimited-mint skips the gate.
+func confirmUnlimitedMint(cmd *cobra.Command, cfg *config.TokenConfig) error {
+	if !cfg.Mintable || cfg.MaxSupply != "" {
+		return nil
+	}
+	if allow, _ := cmd.Flags().GetBool("allow-unlimited-mint"); allow {
+		return nil
+	}
+	switch {
+	case cfg.Strict:
+		return errors.New("mintable without --max-supply allows unlimited inflation — set --max-supply or pass --allow-unlimited-mint")
+	case usesPrompts(cmd):
+		ok, err := prompts.ConfirmUnlimitedMint()
+		if err != nil {
+			return fmt.Errorf("prompt error: %w", err)
+		}
+		if !ok {
+			return errors.New("generation canceled — set a max supply or pass --allow-unlimited-mint")
+		}
+	default:
+		fmt.Fprintln(cmd.ErrOrStderr(), "⚠️  mintable without --max-supply allows unlimited inflation — set --max-supply, or pass --allow-unlimited-mint to silence this warning")
+	}
+	return nil
+}
+
+// compileContract runs the local Solidity compiler over the written
+// contract. A missing compiler, or a contract that only exists inside a zip
+// archive, is a warning rather than an error.
+func compileContract(ctx context.Context, path string, zipped bool) error {
+	if zipped {
+		logger.Warn("⚠️  --compile skipped: the contract was written into an archive, not to disk")
+		return nil
+	}
+	err := solc.CompileContext(ctx, path)
+	switch {
+	case errors.Is(err, solc.ErrNotFound):
+		logger.Warn(fmt.Sprintf("⚠️  --compile skipped: %s", err))
+		return nil
+	case err != nil:
+		return fmt.Errorf("compilation failed: %w", err)
 	}
+	logger.Info("✅ Contract compiles: " + path)
+	return nil
+}
 
-	gen := generator.New(cfg)
+// writeArtifacts renders every requested file through the library API and
+// hands each one to out.
+func writeArtifacts(cmd *cobra.Command, cfg *config.TokenConfig, paths outputPaths, out artifactWriter) error {
+	seed := time.Now().UnixNano()
+	if cmd.Flags().Changed("seed") {
+		seed, _ = cmd.Flags().GetInt64("seed")
+	}
 
-	// Write contract
-	contractPath := filepath.Join(outDir, cfg.ContractFileName())
-	contract, err := gen.GenerateContract()
+	layout, _ := cmd.Flags().GetString("layout")
+	singleFile, _ := cmd.Flags().GetBool("output-single-file")
+	check, _ := cmd.Flags().GetBool("check")
+	diffDir, _ := cmd.Flags().GetString("diff-against")
+	compareOnly := check || diffDir != ""
+	files, err := erc20gen.GenerateContext(cmd.Context(), cfg, erc20gen.Options{Layout: layout, Seed: seed, SingleFile: singleFile, Logger: logger})
 	if err != nil {
-		return fmt.Errorf("contract generation failed: %w", err)
+		return err
 	}
-	if err := os.WriteFile(contractPath, []byte(contract), 0640); err != nil {
-		return fmt.Errorf("failed to write contract: %w", err)
+	rel, err := erc20gen.LayoutPaths(cfg, layout)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("✅ Contract generated: %s\n", contractPath)
 
-	// Optional deploy script
-	withDeploy, _ := cmd.Flags().GetBool("with-deploy")
-	if cfg.WithDeploy || withDeploy {
-		deployPath := filepath.Join(outDir, "..", "scripts", "deploy_"+cfg.SafeName()+".js")
-		_ = os.MkdirAll(filepath.Dir(deployPath), 0750)
-		deploy, err := gen.GenerateDeployScript()
-		if err != nil {
-			return fmt.Errorf("deploy script generation failed: %w", err)
+	// Contract first and README last, matching the order files are reported
+	for _, a := range []struct{ label, rel, dst string }{
+		{"Contract", rel.Contract, paths.Contract},
+		{"Governor", rel.Governor, paths.Governor},
+		{"Deploy script", rel.Deploy, paths.Deploy},
+		{"Test skeleton", rel.Test, paths.Test},
+		{"Invariant test", rel.Invariants, paths.Invariants},
+		{"ABI", rel.ABI, paths.ABI},
+		{"CI workflow", rel.CI, paths.CI},
+		{"Env example", rel.Env, paths.Env},
+		{"TypeChain config", rel.Typechain, paths.Typechain},
+		{"Compiler config", rel.Compiler, paths.Compiler},
+		{"Foundry config", rel.Foundry, paths.Foundry},
+		{"README", rel.Readme, paths.Readme},
+	} {
+		content, ok := files[a.rel]
+		if !ok {
+			continue
 		}
-		if err := os.WriteFile(deployPath, []byte(deploy), 0640); err != nil {
-			return fmt.Errorf("failed to write deploy script: %w", err)
+		if err := out.Write(a.dst, []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", a.dst, err)
 		}
-		fmt.Printf("✅ Deploy script generated: %s\n", deployPath)
+		if compareOnly {
+			logger.Debug("compared file", "path", a.dst, "bytes", len(content))
+			continue
+		}
+		logger.Debug("wrote file", "path", a.dst, "bytes", len(content))
+		logger.Info(fmt.Sprintf("✅ %s generated: %s", a.label, a.dst))
 	}
 
-	// Optional test skeleton
-	withTest, _ := cmd.Flags().GetBool("with-test")
-	if cfg.WithTest || withTest {
-		testPath := filepath.Join(outDir, "..", "test", cfg.SafeName()+".test.js")
-		_ = os.MkdirAll(filepath.Dir(testPath), 0750)
-		test, err := gen.GenerateTestSkeleton()
+	if !compareOnly {
+		logger.Info(fmt.Sprintf("🎲 Seed: %d (pass --seed to reproduce this output)", seed))
+	}
+	return nil
+}
+
+// usesPrompts reports whether the token options come from the interactive
+// prompts: interactive mode is on and neither --name, --symbol nor a wizard
+// export supplies them.
+func usesPrompts(cmd *cobra.Command) bool {
+	interactive, _ := cmd.Flags().GetBool("interactive")
+	name, _ := cmd.Flags().GetString("name")
+	symbol, _ := cmd.Flags().GetString("symbol")
+	wizardPath, _ := cmd.Flags().GetString("from-wizard-json")
+	return interactive && name == "" && symbol == "" && wizardPath == ""
+}
+
+// resolveTokenConfig builds the TokenConfig from the token flags: it fills
+// unset flags from the environment, config file, and preset, then reads the
+// options from a wizard export, the interactive prompts, or the flags.
+func resolveTokenConfig(cmd *cobra.Command) (*config.TokenConfig, error) {
+	var cfg *config.TokenConfig
+	var err error
+
+	if err := applyViperDefaults(cmd); err != nil {
+		return nil, err
+	}
+	preset, _ := cmd.Flags().GetString("preset")
+	if err := applyPresetDefaults(cmd, preset); err != nil {
+		return nil, err
+	}
+
+	wizardPath, _ := cmd.Flags().GetString("from-wizard-json")
+
+	if wizardPath != "" {
+		cfg, err = buildConfigFromWizard(cmd, wizardPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyChangedFlags(cmd, cfg, nil); err != nil {
+			return nil, err
+		}
+	} else if usesPrompts(cmd) {
+		cfg, err = prompts.CollectTokenConfig()
+		if err != nil {
+			return nil, fmt.Errorf("prompt error: %w", err)
+		}
+		if err := applyChangedFlags(cmd, cfg, prompts.PromptedFlags); err != nil {
+			return nil, err
+		}
+	} else {
+		// Build config from flags, prompting for required ones left unset
+		cfg, err = buildConfigFromFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("test skeleton generation failed: %w", err)
+			return nil, err
 		}
-		if err := os.WriteFile(testPath, []byte(test), 0640); err != nil {
-			return fmt.Errorf("failed to write test skeleton: %w", err)
+		if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
+			if err := prompts.FillMissing(cfg); err != nil {
+				return nil, fmt.Errorf("prompt error: %w", err)
+			}
 		}
-		fmt.Printf("✅ Test skeleton generated: %s\n", testPath)
 	}
 
-	fmt.Printf("\n🔐 Security checklist printed to stdout:\n")
-	printSecurityChecklist(cfg)
+	if _, flagPreset := presets[preset]; preset != "" && !flagPreset {
+		// The --access default would otherwise read as an explicit choice.
+		if !cmd.Flags().Changed("access") {
+			cfg.AccessControl = ""
+		}
+		if err := config.ApplyPreset(cfg, preset); err != nil {
+			return nil, fmt.Errorf("--preset %s: %w", preset, err)
+		}
+	}
+
+	if withEvents, _ := cmd.Flags().GetBool("with-events"); !withEvents {
+		cfg.OmitEvents = true
+	}
+	// OZ v5 reverts with custom errors itself; v4 still uses reason strings.
+	customErrors := cfg.OZVersion != config.OZv4
+	if cmd.Flags().Changed("custom-errors") {
+		customErrors, _ = cmd.Flags().GetBool("custom-errors")
+	}
+	cfg.RequireStrings = !customErrors
+	guardStyle, _ := cmd.Flags().GetString("guard-style")
+	cfg.GuardStyle = config.GuardStyle(guardStyle)
+	if checkTicker, _ := cmd.Flags().GetBool("check-ticker"); checkTicker {
+		cfg.CheckTicker = true
+	}
+	if noSupply, _ := cmd.Flags().GetBool("no-initial-supply"); noSupply {
+		cfg.InitialSupply = ""
+	}
+	if err := applyNetworkConfigs(cfg, viper.GetViper()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyNetworkConfigs reads the config file's networks block, which has no
+// flag: a map of Hardhat network name to per-network constructor values.
+func applyNetworkConfigs(cfg *config.TokenConfig, v *viper.Viper) error {
+	if !v.IsSet("networks") {
+		return nil
+	}
+	if err := v.UnmarshalKey("networks", &cfg.NetworkConfigs); err != nil {
+		return fmt.Errorf("invalid networks in config: %w", err)
+	}
+	return nil
+}
+
+// applyViperDefaults fills every flag the user did not pass from viper,
+// which resolves ERC20GEN_* environment variables before the config file.
+// Explicit flags always win.
+func applyViperDefaults(cmd *cobra.Command) error {
+	return applyConfigDefaults(cmd, viper.GetViper())
+}
+
+// applyConfigDefaults fills every unchanged flag that v has a value for.
+func applyConfigDefaults(cmd *cobra.Command, v *viper.Viper) error {
+	var errs []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed || !v.IsSet(f.Name) {
+			return
+		}
+		if err := cmd.Flags().Set(f.Name, v.GetString(f.Name)); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", f.Name, err))
+		}
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid defaults from environment/config:\n  - %s", strings.Join(errs, "\n  - "))
+	}
 	return nil
 }
 
+// parseFileMode parses an octal permission string such as "0640".
+func parseFileMode(s string) (os.FileMode, error) {
+	v, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid octal mode", s)
+	}
+	if v&^0o777 != 0 {
+		return 0, fmt.Errorf("%q sets bits outside 0777", s)
+	}
+	return os.FileMode(v), nil
+}
+
 func buildConfigFromFlags(cmd *cobra.Command) (*config.TokenConfig, error) {
 	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
 	symbol, _ := cmd.Flags().GetString("symbol")
+	symbolCase, _ := cmd.Flags().GetString("symbol-case")
 	decimals, _ := cmd.Flags().GetUint8("decimals")
 	initialSupply, _ := cmd.Flags().GetString("initial-supply")
 	maxSupply, _ := cmd.Flags().GetString("max-supply")
+	fixedSupply, _ := cmd.Flags().GetBool("fixed-supply")
+	emitCapReached, _ := cmd.Flags().GetBool("emit-cap-reached")
+	initialHolder, _ := cmd.Flags().GetString("initial-holder")
+	allocationFlags, _ := cmd.Flags().GetStringArray("allocation")
 	mintable, _ := cmd.Flags().GetBool("mintable")
+	airdrop, _ := cmd.Flags().GetBool("with-airdrop")
+	airdropRestricted, _ := cmd.Flags().GetBool("airdrop-restricted")
+	merkleClaim, _ := cmd.Flags().GetBool("with-merkle-claim")
+	merkleRoot, _ := cmd.Flags().GetString("merkle-root")
+	metadata, _ := cmd.Flags().GetBool("with-metadata")
+	metadataURI, _ := cmd.Flags().GetString("metadata-uri")
+	burnRebase, _ := cmd.Flags().GetBool("with-burn-rebase")
+	burnRebaseMaxBps, _ := cmd.Flags().GetInt("burn-rebase-max-bps")
+	bridge, _ := cmd.Flags().GetString("bridge")
+	mintSchedule, _ := cmd.Flags().GetString("mint-schedule")
+	emissionRate, _ := cmd.Flags().GetString("emission-rate")
+	emissionStart, _ := cmd.Flags().GetInt64("emission-start")
 	burnable, _ := cmd.Flags().GetBool("burnable")
+	adminBurn, _ := cmd.Flags().GetBool("admin-burn")
+	locks, _ := cmd.Flags().GetBool("with-locks")
+	rejectSelfTransfer, _ := cmd.Flags().GetBool("reject-self-transfer")
+	reentrancyGuard, _ := cmd.Flags().GetBool("reentrancy-guard")
 	pausable, _ := cmd.Flags().GetBool("pausable")
+	startPaused, _ := cmd.Flags().GetBool("start-paused")
 	permit, _ := cmd.Flags().GetBool("permit")
 	snapshot, _ := cmd.Flags().GetBool("snapshot")
+	snapshotOnDeploy, _ := cmd.Flags().GetBool("snapshot-on-deploy")
 	votes, _ := cmd.Flags().GetBool("votes")
+	clockMode, _ := cmd.Flags().GetString("clock-mode")
+	withGovernor, _ := cmd.Flags().GetBool("with-governor")
+	votingDelay, _ := cmd.Flags().GetInt64("voting-delay")
+	votingPeriod, _ := cmd.Flags().GetInt64("voting-period")
+	quorumPercent, _ := cmd.Flags().GetUint8("quorum-percent")
 	access, _ := cmd.Flags().GetString("access")
+	adminAddress, _ := cmd.Flags().GetString("admin-address")
+	upgradeable, _ := cmd.Flags().GetString("upgradeable")
 	license, _ := cmd.Flags().GetString("license")
+	title, _ := cmd.Flags().GetString("title")
+	author, _ := cmd.Flags().GetString("author")
+	notice, _ := cmd.Flags().GetString("notice")
 	solidityVersion, _ := cmd.Flags().GetString("solidity-version")
+	evmVersion, _ := cmd.Flags().GetString("evm-version")
+	ozVersion, _ := cmd.Flags().GetString("oz-version")
+	network, _ := cmd.Flags().GetString("network")
+	ctorParams, _ := cmd.Flags().GetStringArray("ctor-param")
+	exposeParams, _ := cmd.Flags().GetBool("expose-params")
+	extraImports, _ := cmd.Flags().GetStringArray("extra-import")
+	extraParents, _ := cmd.Flags().GetStringArray("extra-parent")
 	withDeploy, _ := cmd.Flags().GetBool("with-deploy")
+	deployStyle, _ := cmd.Flags().GetString("deploy-style")
 	withTest, _ := cmd.Flags().GetBool("with-test")
+	testStyle, _ := cmd.Flags().GetString("test-style")
+	withABI, _ := cmd.Flags().GetBool("with-abi")
+	withReadme, _ := cmd.Flags().GetBool("with-readme")
+	withCI, _ := cmd.Flags().GetBool("with-ci")
+	withEnv, _ := cmd.Flags().GetBool("with-env")
+	withTypechain, _ := cmd.Flags().GetBool("with-typechain")
+
+	var allocations []config.Allocation
+	for _, s := range allocationFlags {
+		a, err := config.ParseAllocation(s)
+		if err != nil {
+			return nil, fmt.Errorf("--allocation: %w", err)
+		}
+		allocations = append(allocations, a)
+	}
 
 	return &config.TokenConfig{
-		Name:            name,
-		Symbol:          symbol,
-		Decimals:        decimals,
-		InitialSupply:   initialSupply,
-		MaxSupply:       maxSupply,
-		Mintable:        mintable,
-		Burnable:        burnable,
-		Pausable:        pausable,
-		Permit:          permit,
-		Snapshot:        snapshot,
-		Votes:           votes,
-		AccessControl:   config.AccessControlType(access),
-		License:         license,
-		SolidityVersion: solidityVersion,
-		WithDeploy:      withDeploy,
-		WithTest:        withTest,
+		Name:                   name,
+		DisplayName:            displayName,
+		Symbol:                 symbol,
+		SymbolCase:             config.SymbolCase(symbolCase),
+		Decimals:               decimals,
+		InitialSupply:          initialSupply,
+		MaxSupply:              maxSupply,
+		FixedSupply:            fixedSupply,
+		EmitCapReached:         emitCapReached,
+		InitialHolder:          initialHolder,
+		Allocations:            allocations,
+		Mintable:               mintable,
+		Airdrop:                airdrop,
+		AirdropRestricted:      airdropRestricted,
+		MerkleClaim:            merkleClaim,
+		MerkleRoot:             merkleRoot,
+		Metadata:               metadata,
+		MetadataURI:            metadataURI,
+		BurnRebase:             burnRebase,
+		BurnRebaseMaxBps:       burnRebaseMaxBps,
+		BridgeMinter:           bridge,
+		MintSchedule:           config.MintScheduleType(mintSchedule),
+		EmissionRatePerSecond:  emissionRate,
+		EmissionStart:          emissionStart,
+		Burnable:               burnable,
+		AdminBurn:              adminBurn,
+		Locks:                  locks,
+		RejectSelfTransfer:     rejectSelfTransfer,
+		ReentrancyGuard:        reentrancyGuard,
+		Pausable:               pausable,
+		StartPaused:            startPaused,
+		Permit:                 permit,
+		Snapshot:               snapshot,
+		SnapshotOnDeploy:       snapshotOnDeploy,
+		Votes:                  votes,
+		ClockMode:              config.ClockMode(clockMode),
+		WithGovernor:           withGovernor,
+		GovernorVotingDelay:    votingDelay,
+		GovernorVotingPeriod:   votingPeriod,
+		GovernorQuorumPercent:  quorumPercent,
+		AccessControl:          config.AccessControlType(access),
+		AdminAddress:           adminAddress,
+		Upgradeable:            config.UpgradeableType(upgradeable),
+		License:                config.LicenseType(license),
+		Title:                  title,
+		Author:                 author,
+		Notice:                 notice,
+		SolidityVersion:        solidityVersion,
+		EVMVersion:             config.EVMVersion(evmVersion),
+		OZVersion:              config.OZVersion(ozVersion),
+		Network:                network,
+		ExtraConstructorParams: ctorParams,
+		ExposeParams:           exposeParams,
+		ExtraImports:           extraImports,
+		ExtraParents:           extraParents,
+		WithDeploy:             withDeploy,
+		DeployStyle:            config.DeployStyle(deployStyle),
+		WithTest:               withTest,
+		TestStyle:              config.TestStyle(testStyle),
+		WithABI:                withABI,
+		WithReadme:             withReadme,
+		WithCI:                 withCI,
+		WithEnv:                withEnv,
+		WithTypechain:          withTypechain,
 	}, nil
 }
 
+// buildConfigFromWizard loads an OpenZeppelin Wizard export. Token options
+// come from the file; output options still come from flags.
+func buildConfigFromWizard(cmd *cobra.Command, path string) (*config.TokenConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wizard JSON: %w", err)
+	}
+	cfg, err := config.FromWizard(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.License == "" {
+		license, _ := cmd.Flags().GetString("license")
+		cfg.License = config.LicenseType(license)
+	}
+	ozVersion, _ := cmd.Flags().GetString("oz-version")
+	cfg.OZVersion = config.OZVersion(ozVersion)
+	cfg.SolidityVersion, _ = cmd.Flags().GetString("solidity-version")
+	evmVersion, _ := cmd.Flags().GetString("evm-version")
+	cfg.EVMVersion = config.EVMVersion(evmVersion)
+	deployStyle, _ := cmd.Flags().GetString("deploy-style")
+	cfg.DeployStyle = config.DeployStyle(deployStyle)
+	testStyle, _ := cmd.Flags().GetString("test-style")
+	cfg.TestStyle = config.TestStyle(testStyle)
+	cfg.Title, _ = cmd.Flags().GetString("title")
+	cfg.Author, _ = cmd.Flags().GetString("author")
+	cfg.Notice, _ = cmd.Flags().GetString("notice")
+	cfg.WithGovernor, _ = cmd.Flags().GetBool("with-governor")
+	cfg.GovernorVotingDelay, _ = cmd.Flags().GetInt64("voting-delay")
+	cfg.GovernorVotingPeriod, _ = cmd.Flags().GetInt64("voting-period")
+	cfg.GovernorQuorumPercent, _ = cmd.Flags().GetUint8("quorum-percent")
+	return cfg, nil
+}
+
+// applyChangedFlags copies onto cfg every field whose flag was set — on the
+// command line, or from the environment, config file or preset — except
+// those in skip, taking the value the flags alone would give it. Wizard
+// imports and the prompts use it so a flag they do not cover is never
+// dropped. It runs before Validate, so the usual checks still apply.
+func applyChangedFlags(cmd *cobra.Command, cfg *config.TokenConfig, skip map[string]bool) error {
+	fromFlags, err := buildConfigFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	dst, src := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(fromFlags).Elem()
+	for i := 0; i < dst.NumField(); i++ {
+		name, ok := dst.Type().Field(i).Tag.Lookup("flag")
+		if !ok || skip[name] || !cmd.Flags().Changed(name) {
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
+	return nil
+}
+
+// printSecurityChecklist logs the pre-deployment checklist for cfg through
+// the command logger, alongside the rest of the generate output.
 func printSecurityChecklist(cfg *config.TokenConfig) {
 	checks := []string{
-		"[ ] Review OpenZeppelin version in package.json — use latest stable",
+		"[ ] Pin @openzeppelin/contracts to " + cfg.OZVersionString() + " in package.json — the contract targets OpenZeppelin v" + string(cfg.OZVersion),
 		"[ ] Audit mint() access control before mainnet deployment",
 		"[ ] Run Slither static analysis: slither contracts/" + cfg.ContractFileName(),
 		"[ ] Run Echidna fuzzer on token invariants",
@@ -189,6 +806,21 @@ func printSecurityChecklist(cfg *config.TokenConfig) {
 		"[ ] Consider front-running risks if using Pausable",
 		"[ ] Test all edge cases: zero transfers, max uint256 approvals",
 	}
+	if cfg.HasMintSchedule() {
+		checks = append(checks, "[ ] Confirm EMISSION_RATE and EMISSION_START — the schedule is immutable once deployed")
+	}
+	if cfg.HasBridge() {
+		checks = append(checks, "[ ] Confirm BRIDGE is the bridge's mint/burn contract on this chain — it is a constant and can mint without limit")
+	}
+	if cfg.MerkleClaim {
+		checks = append(checks, "[ ] Rebuild the claim tree off-chain and confirm it matches MERKLE_ROOT — the root is a constant and claims mint new supply")
+	}
+	if cfg.AdminBurn {
+		checks = append(checks, "[ ] Admin burn is a centralization risk — disclose it and secure the burner key (multisig)")
+	}
+	if cfg.Locks {
+		checks = append(checks, "[ ] Balance locks let the admin freeze holders' tokens — disclose them and secure the locker key (multisig)")
+	}
 	if cfg.Permit {
 		checks = append(checks, "[ ] Validate EIP-712 domain separator is network-specific")
 	}
@@ -198,7 +830,15 @@ func printSecurityChecklist(cfg *config.TokenConfig) {
 	if cfg.Votes {
 		checks = append(checks, "[ ] Governance voting delay and quorum must be reviewed carefully")
 	}
+	if cfg.IsUpgradeable() {
+		checks = append(checks, "[ ] Validate storage layout compatibility before every upgrade (npx hardhat upgrades validate)")
+	}
+	if cfg.IsUUPS() {
+		checks = append(checks, "[ ] Protect the upgrade key — _authorizeUpgrade controls the implementation")
+	}
+	logger.Info("")
+	logger.Info("🔐 Security checklist:")
 	for _, c := range checks {
-		fmt.Println(" ", c)
+		logger.Info("  " + c)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,881 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/Zubimendi/erc20gen/internal/prompts"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Helper ──────────────────────────────────────────────────────────────────
+
+// resetGenerateFlags restores every generate flag to its default, since
+// cobra commands are package-level singletons shared between tests.
+func resetGenerateFlags() {
+	resetFlags(generateCmd)
+}
+
+// resetFlags restores every flag of c to its default.
+func resetFlags(c *cobra.Command) {
+	c.Flags().VisitAll(func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil) // Set would append to the slice
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// executeGenerate runs `erc20gen generate` with the given args on freshly
+// reset flags.
+func executeGenerate(t *testing.T, args ...string) error {
+	t.Helper()
+	resetGenerateFlags()
+	rootCmd.SetArgs(append([]string{"generate", "--interactive=false"}, args...))
+	return rootCmd.Execute()
+}
+
+// inputAsker answers survey.Input prompts by message and records every
+// message it was asked.
+type inputAsker struct {
+	answers map[string]string
+	asked   []string
+}
+
+func (a *inputAsker) answer(p survey.Prompt) (string, error) {
+	in, ok := p.(*survey.Input)
+	if !ok {
+		return "", fmt.Errorf("unexpected prompt %T", p)
+	}
+	a.asked = append(a.asked, in.Message)
+	v, ok := a.answers[in.Message]
+	if !ok {
+		return "", fmt.Errorf("unexpected prompt %q", in.Message)
+	}
+	return v, nil
+}
+
+func (a *inputAsker) Ask(qs []*survey.Question, response interface{}, _ ...survey.AskOpt) error {
+	for _, q := range qs {
+		v, err := a.answer(q.Prompt)
+		if err != nil {
+			return err
+		}
+		if err := core.WriteAnswer(response, q.Name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *inputAsker) AskOne(p survey.Prompt, response interface{}, _ ...survey.AskOpt) error {
+	v, err := a.answer(p)
+	if err != nil {
+		return err
+	}
+	return core.WriteAnswer(response, "", v)
+}
+
+// promptAsker answers every prompt type by message, for driving the full
+// interactive flow from a single answer per prompt.
+type promptAsker struct {
+	answers map[string]interface{}
+}
+
+func (a *promptAsker) answer(p survey.Prompt) (interface{}, error) {
+	var msg string
+	switch p := p.(type) {
+	case *survey.Input:
+		msg = p.Message
+	case *survey.Select:
+		msg = p.Message
+	case *survey.MultiSelect:
+		msg = p.Message
+	case *survey.Confirm:
+		msg = p.Message
+	default:
+		return nil, fmt.Errorf("unexpected prompt %T", p)
+	}
+	v, ok := a.answers[msg]
+	if !ok {
+		return nil, fmt.Errorf("unexpected prompt %q", msg)
+	}
+	return v, nil
+}
+
+func (a *promptAsker) Ask(qs []*survey.Question, response interface{}, _ ...survey.AskOpt) error {
+	for _, q := range qs {
+		v, err := a.answer(q.Prompt)
+		if err != nil {
+			return err
+		}
+		if err := core.WriteAnswer(response, q.Name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *promptAsker) AskOne(p survey.Prompt, response interface{}, _ ...survey.AskOpt) error {
+	v, err := a.answer(p)
+	if err != nil {
+		return err
+	}
+	return core.WriteAnswer(response, "", v)
+}
+
+// ─── File Mode Tests ─────────────────────────────────────────────────────────
+
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{"default file", "0640", 0o640, false},
+		{"default dir", "0750", 0o750, false},
+		{"without leading zero", "644", 0o644, false},
+		{"not octal", "0789", 0, true},
+		{"setuid bit", "4755", 0, true},
+		{"empty", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFileMode(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerate_CustomFileMode(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "contracts")
+	err := executeGenerate(t,
+		"--name", "ModeToken", "--symbol", "MODE",
+		"--out", out,
+		"--layout", "flat",
+		"--file-mode", "0604",
+		"--dir-mode", "0705",
+	)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(out, "ModeToken.sol"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o604), info.Mode().Perm())
+
+	info, err = os.Stat(out)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o705), info.Mode().Perm())
+}
+
+func TestGenerate_InvalidFileMode(t *testing.T) {
+	err := executeGenerate(t,
+		"--name", "ModeToken", "--symbol", "MODE",
+		"--out", t.TempDir(),
+		"--file-mode", "rw-r--r--",
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--file-mode")
+}
+
+// ─── Environment Tests ───────────────────────────────────────────────────────
+
+func TestGenerate_EnvVarProvidesDefault(t *testing.T) {
+	t.Setenv("ERC20GEN_DECIMALS", "6")
+	out := t.TempDir()
+	err := executeGenerate(t, "--name", "EnvToken", "--symbol", "ENV", "--out", out, "--layout", "flat")
+	require.NoError(t, err)
+
+	contract, err := os.ReadFile(filepath.Join(out, "EnvToken.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "return 6;")
+}
+
+func TestGenerate_FlagOverridesEnvVar(t *testing.T) {
+	t.Setenv("ERC20GEN_DECIMALS", "6")
+	out := t.TempDir()
+	err := executeGenerate(t, "--name", "EnvToken", "--symbol", "ENV", "--out", out, "--layout", "flat", "--decimals", "8")
+	require.NoError(t, err)
+
+	contract, err := os.ReadFile(filepath.Join(out, "EnvToken.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "return 8;")
+}
+
+func TestGenerate_InvalidEnvVar(t *testing.T) {
+	t.Setenv("ERC20GEN_DECIMALS", "many")
+	err := executeGenerate(t, "--name", "EnvToken", "--symbol", "ENV", "--out", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decimals")
+}
+
+// ─── Layout Tests ────────────────────────────────────────────────────────────
+
+func TestResolvePaths(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "My Token"}
+	root := "proj"
+	tests := []struct {
+		layout string
+		want   outputPaths
+	}{
+		{"hardhat", outputPaths{
+			Contract:   filepath.Join(root, "contracts", "My_Token.sol"),
+			Governor:   filepath.Join(root, "contracts", "My_TokenGovernor.sol"),
+			Deploy:     filepath.Join(root, "scripts", "deploy_My_Token.js"),
+			Test:       filepath.Join(root, "test", "My_Token.test.js"),
+			Invariants: filepath.Join(root, "test", "My_TokenInvariants.t.sol"),
+			ABI:        filepath.Join(root, "contracts", "My_Token.abi.json"),
+			CI:         filepath.Join(root, ".github", "workflows", "contracts.yml"),
+			Env:        filepath.Join(root, ".env.example"),
+			Typechain:  filepath.Join(root, "typechain.config.js"),
+			Compiler:   filepath.Join(root, "compiler.config.js"),
+			Foundry:    filepath.Join(root, "foundry.toml"),
+			Readme:     filepath.Join(root, "README.md"),
+		}},
+		{"foundry", outputPaths{
+			Contract:   filepath.Join(root, "src", "My_Token.sol"),
+			Governor:   filepath.Join(root, "src", "My_TokenGovernor.sol"),
+			Deploy:     filepath.Join(root, "script", "deploy_My_Token.js"),
+			Test:       filepath.Join(root, "test", "My_Token.test.js"),
+			Invariants: filepath.Join(root, "test", "My_TokenInvariants.t.sol"),
+			ABI:        filepath.Join(root, "src", "My_Token.abi.json"),
+			CI:         filepath.Join(root, ".github", "workflows", "contracts.yml"),
+			Env:        filepath.Join(root, ".env.example"),
+			Typechain:  filepath.Join(root, "typechain.config.js"),
+			Compiler:   filepath.Join(root, "compiler.config.js"),
+			Foundry:    filepath.Join(root, "foundry.toml"),
+			Readme:     filepath.Join(root, "README.md"),
+		}},
+		{"flat", outputPaths{
+			Contract:   filepath.Join(root, "My_Token.sol"),
+			Governor:   filepath.Join(root, "My_TokenGovernor.sol"),
+			Deploy:     filepath.Join(root, "deploy_My_Token.js"),
+			Test:       filepath.Join(root, "My_Token.test.js"),
+			Invariants: filepath.Join(root, "My_TokenInvariants.t.sol"),
+			ABI:        filepath.Join(root, "My_Token.abi.json"),
+			CI:         filepath.Join(root, ".github", "workflows", "contracts.yml"),
+			Env:        filepath.Join(root, ".env.example"),
+			Typechain:  filepath.Join(root, "typechain.config.js"),
+			Compiler:   filepath.Join(root, "compiler.config.js"),
+			Foundry:    filepath.Join(root, "foundry.toml"),
+			Readme:     filepath.Join(root, "README.md"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, err := resolvePaths(cfg, tt.layout, root)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolvePaths_InvalidLayout(t *testing.T) {
+	_, err := resolvePaths(&config.TokenConfig{Name: "X"}, "truffle", ".")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid layout")
+}
+
+func TestGenerate_InteractivePromptsOnlyForMissingFields(t *testing.T) {
+	asker := &inputAsker{answers: map[string]string{"Token Symbol (uppercase):": "PRM"}}
+	t.Cleanup(prompts.SetAsker(asker))
+
+	root := t.TempDir()
+	resetGenerateFlags()
+	rootCmd.SetArgs([]string{"generate", "--name", "Prompted", "--decimals", "6", "--out", root, "--seed", "1"})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Equal(t, []string{"Token Symbol (uppercase):"}, asker.asked)
+	contract, err := os.ReadFile(filepath.Join(root, "contracts", "Prompted.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), `ERC20("Prompted", "PRM")`)
+	assert.Contains(t, string(contract), "return 6;")
+}
+
+func TestGenerate_FullPromptsKeepNonPromptedFlags(t *testing.T) {
+	t.Cleanup(prompts.SetAsker(&promptAsker{answers: map[string]interface{}{
+		"Advanced options?":                   true,
+		"Token Name:":                         "Prompted",
+		"Token Symbol (uppercase):":           "PRM",
+		"Decimals:":                           "18",
+		"Initial Supply (whole tokens):":      "1000",
+		"Set a maximum supply cap?":           false,
+		"Select token features:":              []int{6}, // Votes
+		"Votes clock mode:":                   "blocknumber",
+		"Access Control Model:":               "ownable",
+		"Upgradeability:":                     "none",
+		"Generate Hardhat deployment script?": false,
+		"Generate Hardhat test skeleton?":     false,
+		"Generate ABI JSON?":                  false,
+		"License:":                            "MIT",
+		"Generate these files?":               true,
+	}}))
+
+	root := t.TempDir()
+	resetGenerateFlags()
+	rootCmd.SetArgs([]string{"generate", "--oz-version", "4", "--out", root, "--seed", "1"})
+	require.NoError(t, rootCmd.Execute())
+
+	contract, err := os.ReadFile(filepath.Join(root, "contracts", "Prompted.sol"))
+	require.NoError(t, err)
+	src := string(contract)
+	assert.Contains(t, src, "ERC20Votes")
+	assert.Contains(t, src, "ERC20Snapshot", "v4 votes implies snapshot")
+	assert.Contains(t, src, "_afterTokenTransfer")
+	assert.NotContains(t, src, "_update(")
+}
+
+func TestGenerate_HardhatLayoutWritesAllFiles(t *testing.T) {
+	root := t.TempDir()
+	err := executeGenerate(t,
+		"--name", "LayoutToken", "--symbol", "LAY",
+		"--out", root,
+		"--with-deploy", "--with-test",
+	)
+	require.NoError(t, err)
+
+	for _, p := range []string{"contracts/LayoutToken.sol", "scripts/deploy_LayoutToken.js", "test/LayoutToken.test.js"} {
+		assert.FileExists(t, filepath.Join(root, p))
+	}
+}
+
+// ─── Wizard Import Tests ─────────────────────────────────────────────────────
+
+func TestGenerate_FromWizardJSON(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "wizard.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"name":"WizToken","symbol":"WIZ","premint":"500","mintable":true,"permit":true,"access":"ownable"}`), 0o600))
+
+	out := filepath.Join(dir, "out")
+	err := executeGenerate(t, "--from-wizard-json", spec, "--out", out, "--layout", "flat")
+	require.NoError(t, err)
+
+	contract, err := os.ReadFile(filepath.Join(out, "WizToken.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "contract WizToken is ERC20, ERC20Permit, Ownable")
+	assert.Contains(t, string(contract), "function mint(")
+}
+
+func TestGenerate_FromWizardJSONAppliesFlags(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "wizard.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"name":"WizToken","symbol":"WIZ","premint":"500","mintable":true,"access":"ownable"}`), 0o600))
+
+	out := filepath.Join(dir, "out")
+	err := executeGenerate(t, "--from-wizard-json", spec, "--out", out, "--layout", "flat", "--upgradeable", "uups")
+	require.NoError(t, err)
+	contract, err := os.ReadFile(filepath.Join(out, "WizToken.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "UUPSUpgradeable")
+
+	err = executeGenerate(t, "--from-wizard-json", spec, "--out", out, "--layout", "flat", "--with-test", "--test-style", "viem-ts")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(out, "WizToken.test.ts"))
+
+	err = executeGenerate(t, "--from-wizard-json", spec, "--out", out, "--layout", "flat",
+		"--with-typechain", "--test-style", "viem-ts")
+	require.ErrorContains(t, err, "--with-typechain targets ethers")
+}
+
+func TestGenerate_FromWizardJSONKeepsTokenAndDeployFlags(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "wizard.json")
+	require.NoError(t, os.WriteFile(spec, []byte(`{"name":"WizToken","symbol":"WIZ","premint":"500","access":"ownable"}`), 0o600))
+
+	out := filepath.Join(dir, "out")
+	err := executeGenerate(t, "--from-wizard-json", spec, "--out", out, "--layout", "flat", "--with-deploy",
+		"--network", "sepolia", "--initial-holder", "0x000000000000000000000000000000000000dEaD")
+	require.NoError(t, err)
+
+	contract, err := os.ReadFile(filepath.Join(out, "WizToken.sol"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "_mint(0x000000000000000000000000000000000000dEaD, 500 * 10 ** decimals());")
+	deploy, err := os.ReadFile(filepath.Join(out, "deploy_WizToken.js"))
+	require.NoError(t, err)
+	assert.Contains(t, string(deploy), "Expected sepolia (chainId 11155111)")
+}
+
+// ─── Preset Tests ────────────────────────────────────────────────────────────
+
+func TestGenerate_ImmutablePreset(t *testing.T) {
+	out := t.TempDir()
+	err := executeGenerate(t, "--name", "Fixed", "--symbol", "FIX", "--initial-supply", "1000", "--preset", "immutable", "--out", out, "--layout", "flat")
+	require.NoError(t, err)
+
+	contract, err := os.ReadFile(filepath.Join(out, "Fixed.sol"))
+	require.NoError(t, err)
+	src := string(contract)
+	assert.Contains(t, src, "contract Fixed is ERC20 {")
+	assert.Contains(t, src, "_mint(msg.sender, 1_000 * 10 ** decimals());")
+	assert.NotContains(t, src, "Ownable")
+	assert.NotContains(t, src, "function mint(")
+	assert.NotContains(t, src, "function pause(")
+}
+
+func TestGenerate_ImmutablePresetRejectsMintable(t *testing.T) {
+	err := executeGenerate(t, "--name", "Fixed", "--symbol", "FIX", "--initial-supply", "1000", "--preset", "immutable", "--mintable", "--out", t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--mintable conflicts with the immutable preset")
+}
+
+func TestGenerate_NoInitialSupply(t *testing.T) {
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	out := t.TempDir()
+	err := executeGenerate(t, "--name", "Empty", "--symbol", "EMP", "--initial-supply", "1000", "--no-initial-supply", "--mintable", "--max-supply", "5000", "--out", out, "--layout", "flat")
+	require.NoError(t, err)
+	contract, err := os.ReadFile(filepath.Join(out, "Empty.sol"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(contract), "_mint(msg.sender")
+	assert.NotContains(t, string(contract), "_mint(initialOwner")
+	assert.Contains(t, string(contract), "function mint(")
+	assert.NotContains(t, stdout.String(), "can never have a nonzero supply")
+
+	stdout.Reset()
+	err = executeGenerate(t, "--name", "Empty", "--symbol", "EMP", "--preset", "meme", "--no-initial-supply", "--out", t.TempDir())
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "can never have a nonzero supply")
+}
+
+func TestGenerate_CustomErrorsDefaultFollowsOZVersion(t *testing.T) {
+	rootCmd.SetOut(io.Discard)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"v5 default", nil, "revert BatchLengthMismatch("},
+		{"v4 default", []string{"--oz-version", "4"}, `require(recipients.length == amounts.length, "Errs: array length mismatch");`},
+		{"v4 opt in", []string{"--oz-version", "4", "--custom-errors"}, "revert BatchLengthMismatch("},
+		{"v5 opt out", []string{"--custom-errors=false"}, `"Errs: array length mismatch"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := t.TempDir()
+			args := append([]string{"--name", "Errs", "--symbol", "ERR", "--initial-supply", "1000", "--with-airdrop", "--out", out, "--layout", "flat"}, tt.args...)
+			require.NoError(t, executeGenerate(t, args...))
+			contract, err := os.ReadFile(filepath.Join(out, "Errs.sol"))
+			require.NoError(t, err)
+			assert.Contains(t, string(contract), tt.want)
+		})
+	}
+}
+
+func TestApplyPresetDefaults(t *testing.T) {
+	tests := []struct {
+		preset string
+		want   config.TokenConfig
+	}{
+		{"stablecoin", config.TokenConfig{Decimals: 6, Mintable: true, Pausable: true, AccessControl: config.AccessRoles}},
+		{"governance", config.TokenConfig{Decimals: 18, Votes: true, Permit: true, Snapshot: true, AccessControl: config.AccessOwnable}},
+		{"meme", config.TokenConfig{Decimals: 18, InitialSupply: "1000000000", Burnable: true, AccessControl: config.AccessNone}},
+		{"utility", config.TokenConfig{Decimals: 18, Mintable: true, Burnable: true, AccessControl: config.AccessOwnable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			resetGenerateFlags()
+			require.NoError(t, applyPresetDefaults(generateCmd, tt.preset))
+			cfg, err := buildConfigFromFlags(generateCmd)
+			require.NoError(t, err)
+
+			got := config.TokenConfig{
+				Decimals:      cfg.Decimals,
+				InitialSupply: cfg.InitialSupply,
+				Mintable:      cfg.Mintable,
+				Burnable:      cfg.Burnable,
+				Pausable:      cfg.Pausable,
+				Permit:        cfg.Permit,
+				Snapshot:      cfg.Snapshot,
+				Votes:         cfg.Votes,
+				AccessControl: cfg.AccessControl,
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyPresetDefaults_ExplicitFlagWins(t *testing.T) {
+	resetGenerateFlags()
+	require.NoError(t, generateCmd.Flags().Parse([]string{"--decimals", "8", "--pausable=false"}))
+	require.NoError(t, applyPresetDefaults(generateCmd, "stablecoin"))
+
+	cfg, err := buildConfigFromFlags(generateCmd)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(8), cfg.Decimals)
+	assert.False(t, cfg.Pausable)
+	assert.True(t, cfg.Mintable)
+}
+
+func TestApplyPresetDefaults_Unknown(t *testing.T) {
+	resetGenerateFlags()
+	err := applyPresetDefaults(generateCmd, "degen")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "governance, immutable, meme, stablecoin, utility")
+}
+
+// ─── Zip Output Tests ────────────────────────────────────────────────────────
+
+func TestGenerate_OutZip(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "token.zip")
+	err := executeGenerate(t, "--name", "ZipToken", "--symbol", "ZIP", "--out-zip", archive, "--with-deploy", "--with-test")
+	require.NoError(t, err)
+
+	r, err := zip.OpenReader(archive)
+	require.NoError(t, err)
+	defer r.Close()
+
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(t, []string{"contracts/ZipToken.sol", "scripts/deploy_ZipToken.js", "test/ZipToken.test.js"}, names)
+
+	rc, err := r.File[0].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	contract, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Contains(t, string(contract), "contract ZipToken is ERC20")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no loose files should be written next to the archive")
+}
+
+func TestGenerate_ViemTestStyleWritesTypeScript(t *testing.T) {
+	root := t.TempDir()
+	err := executeGenerate(t, "--name", "ViemToken", "--symbol", "VIEM", "--out", root, "--with-test", "--test-style", "viem-ts")
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(root, "test", "ViemToken.test.ts"))
+	assert.NoFileExists(t, filepath.Join(root, "test", "ViemToken.test.js"))
+}
+
+func TestGenerate_WithReadme(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCmds []string
+	}{
+		{
+			name: "hardhat",
+			args: []string{"--with-deploy", "--with-test", "--network", "sepolia"},
+			wantCmds: []string{
+				"npx hardhat compile",
+				"npx hardhat test test/DocToken.test.js",
+				"npx hardhat run scripts/deploy_DocToken.js --network sepolia",
+			},
+		},
+		{
+			name: "foundry viem",
+			args: []string{"--layout", "foundry", "--with-test", "--test-style", "viem-ts"},
+			wantCmds: []string{
+				"forge build",
+				"npx hardhat test test/DocToken.test.ts",
+				"@nomicfoundation/hardhat-toolbox-viem",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			args := append([]string{"--name", "DocToken", "--symbol", "DOC", "--mintable", "--out", root, "--with-readme"}, tt.args...)
+			require.NoError(t, executeGenerate(t, args...))
+
+			data, err := os.ReadFile(filepath.Join(root, "README.md"))
+			require.NoError(t, err)
+			readme := string(data)
+			assert.Contains(t, readme, "# DocToken (DOC)")
+			assert.Contains(t, readme, "| Symbol | DOC |")
+			assert.Contains(t, readme, "- Mintable")
+			for _, c := range tt.wantCmds {
+				assert.Contains(t, readme, c)
+			}
+		})
+	}
+}
+
+func TestGenerate_CompileSkipsWithoutSolc(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	root := t.TempDir()
+	err := executeGenerate(t, "--name", "NoSolc", "--symbol", "NOS", "--out", root, "--compile")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(root, "contracts", "NoSolc.sol"))
+}
+
+func TestGenerate_CompileReportsErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake compiler is a shell script")
+	}
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "solc"), []byte("#!/bin/sh\necho 'DeclarationError: boom' >&2\nexit 1\n"), 0o755))
+	t.Setenv("PATH", bin)
+
+	err := executeGenerate(t, "--name", "BadSolc", "--symbol", "BAD", "--out", t.TempDir(), "--compile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DeclarationError: boom")
+}
+
+// ─── Post-hook Tests ─────────────────────────────────────────────────────────
+
+func writeHookScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook is a shell script")
+	}
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755))
+	return path
+}
+
+func TestGenerate_PostHookRunsOnEachFile(t *testing.T) {
+	hook := writeHookScript(t, `echo "// $1 formatted" >> "$2"`+"\n")
+	root := t.TempDir()
+	err := executeGenerate(t, "--name", "HookToken", "--symbol", "HOOK", "--out", root, "--with-deploy",
+		"--post-hook", hook+" marker")
+	require.NoError(t, err)
+
+	for _, p := range []string{"contracts/HookToken.sol", "scripts/deploy_HookToken.js"} {
+		data, err := os.ReadFile(filepath.Join(root, p))
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(data), "// marker formatted\n"), "hook did not run on %s", p)
+	}
+}
+
+func TestGenerate_PostHookFailureIsReported(t *testing.T) {
+	hook := writeHookScript(t, "echo 'fmt: parse error' >&2\nexit 3\n")
+	err := executeGenerate(t, "--name", "HookToken", "--symbol", "HOOK", "--out", t.TempDir(), "--post-hook", hook)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HookToken.sol")
+	assert.Contains(t, err.Error(), "fmt: parse error")
+}
+
+func TestGenerate_PostHookRejectsZip(t *testing.T) {
+	err := executeGenerate(t, "--name", "HookToken", "--symbol", "HOOK",
+		"--out-zip", filepath.Join(t.TempDir(), "out.zip"), "--post-hook", "true")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--out-zip")
+}
+
+func TestGenerate_StrictRejectsDeprecatedPattern(t *testing.T) {
+	args := []string{"--name", "SnapToken", "--symbol", "SNAP", "--snapshot", "--oz-version", "5"}
+
+	require.NoError(t, executeGenerate(t, append(args, "--out", t.TempDir())...), "lenient mode only warns")
+
+	err := executeGenerate(t, append(args, "--out", t.TempDir(), "--strict")...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ERC20Snapshot was removed")
+}
+
+// ─── Unlimited Mint Tests ────────────────────────────────────────────────────
+
+func TestGenerate_UnlimitedMintWarnsOnStderr(t *testing.T) {
+	var stderr bytes.Buffer
+	rootCmd.SetErr(&stderr)
+	t.Cleanup(func() { rootCmd.SetErr(nil) })
+
+	root := t.TempDir()
+	require.NoError(t, executeGenerate(t, "--name", "MintToken", "--symbol", "MNT", "--out", root, "--mintable"))
+	assert.Contains(t, stderr.String(), "mintable without --max-supply allows unlimited inflation")
+	assert.FileExists(t, filepath.Join(root, "contracts", "MintToken.sol"))
+
+	stderr.Reset()
+	require.NoError(t, executeGenerate(t, "--name", "MintToken", "--symbol", "MNT", "--out", t.TempDir(), "--mintable", "--max-supply", "1000000"))
+	assert.NotContains(t, stderr.String(), "unlimited inflation", "a cap silences the warning")
+
+	stderr.Reset()
+	require.NoError(t, executeGenerate(t, "--name", "MintToken", "--symbol", "MNT", "--out", t.TempDir(), "--mintable", "--allow-unlimited-mint"))
+	assert.NotContains(t, stderr.String(), "unlimited inflation")
+}
+
+func TestGenerate_UnlimitedMintStrictErrors(t *testing.T) {
+	root := t.TempDir()
+	err := executeGenerate(t, "--name", "MintToken", "--symbol", "MNT", "--out", root, "--mintable", "--strict")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unlimited inflation")
+	assert.NoFileExists(t, filepath.Join(root, "contracts", "MintToken.sol"), "the gate runs before writing")
+
+	require.NoError(t, executeGenerate(t, "--name", "MintToken", "--symbol", "MNT", "--out", t.TempDir(), "--mintable", "--strict", "--allow-unlimited-mint"))
+}
+
+// ─── Logging Tests ───────────────────────────────────────────────────────────
+
+func TestGenerate_VerboseLogsInheritance(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	require.NoError(t, executeGenerate(t, "--name", "LogToken", "--symbol", "LOG", "--out", t.TempDir(), "--pausable", "--permit"))
+	assert.NotContains(t, out.String(), "debug:", "debug records are hidden without --verbose")
+	assert.Contains(t, out.String(), "✅ Contract generated:")
+	assert.Contains(t, out.String(), "🔐 Security checklist:\n  [ ] Pin @openzeppelin/contracts", "the checklist goes through the logger")
+
+	out.Reset()
+	require.NoError(t, executeGenerate(t, "--name", "LogToken", "--symbol", "LOG", "--out", t.TempDir(), "--pausable", "--permit", "-v"))
+	assert.Contains(t, out.String(), `debug: resolved inheritance contracts="ERC20Pausable, ERC20Permit, Ownable"`)
+	assert.Contains(t, out.String(), "debug: rendering template template=contract.sol.tmpl")
+	assert.Contains(t, out.String(), "debug: wrote file path=")
+}
+
+// ─── Check Mode Tests ────────────────────────────────────────────────────────
+
+func TestGenerate_CheckPassesOnMatchingFiles(t *testing.T) {
+	root := t.TempDir()
+	args := []string{"--name", "CheckToken", "--symbol", "CHK", "--out", root, "--with-deploy", "--with-test", "--seed", "7"}
+	require.NoError(t, executeGenerate(t, args...))
+
+	require.NoError(t, executeGenerate(t, append(args, "--check")...))
+}
+
+func TestGenerate_CheckReportsDiffOnMismatch(t *testing.T) {
+	root := t.TempDir()
+	args := []string{"--name", "CheckToken", "--symbol", "CHK", "--out", root, "--with-deploy", "--seed", "7"}
+	require.NoError(t, executeGenerate(t, args...))
+
+	contract := filepath.Join(root, "contracts", "CheckToken.sol")
+	data, err := os.ReadFile(contract)
+	require.NoError(t, err)
+	edited := strings.Replace(string(data), `"CHK"`, `"EDIT"`, 1)
+	require.NoError(t, os.WriteFile(contract, []byte(edited), 0o644))
+	before, err := os.ReadFile(contract)
+	require.NoError(t, err)
+
+	err = executeGenerate(t, append(args, "--check")...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 generated file(s) differ")
+	assert.Contains(t, err.Error(), contract+" (committed)")
+	assert.Contains(t, err.Error(), "@@")
+	assert.Contains(t, err.Error(), `-        ERC20("CheckToken", "EDIT")`)
+	assert.Contains(t, err.Error(), `+        ERC20("CheckToken", "CHK")`)
+
+	after, err := os.ReadFile(contract)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "--check must not write files")
+}
+
+func TestGenerate_CheckReportsMissingFile(t *testing.T) {
+	err := executeGenerate(t, "--name", "CheckToken", "--symbol", "CHK", "--out", t.TempDir(), "--seed", "7", "--check")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "(missing)")
+}
+
+func TestGenerate_CheckRequiresSeed(t *testing.T) {
+	err := executeGenerate(t, "--name", "CheckToken", "--symbol", "CHK", "--out", t.TempDir(), "--check")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--seed")
+}
+
+func TestApplyNetworkConfigs_ReadsNetworksBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: Multi
+networks:
+  mainnet:
+    owner: "0x1111111111111111111111111111111111111111"
+  sepolia:
+    treasury: "0x3333333333333333333333333333333333333333"
+`), 0o600))
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+
+	cfg := &config.TokenConfig{}
+	require.NoError(t, applyNetworkConfigs(cfg, v))
+	assert.Equal(t, map[string]config.NetworkParams{
+		"mainnet": {Owner: "0x1111111111111111111111111111111111111111"},
+		"sepolia": {Treasury: "0x3333333333333333333333333333333333333333"},
+	}, cfg.NetworkConfigs)
+
+	cfg = &config.TokenConfig{}
+	require.NoError(t, applyNetworkConfigs(cfg, viper.New()))
+	assert.Nil(t, cfg.NetworkConfigs)
+}
+
+// ─── Diff Tests ──────────────────────────────────────────────────────────────
+
+func TestGenerate_DiffAgainstShowsPausableChange(t *testing.T) {
+	prev := t.TempDir()
+	args := []string{"--name", "DiffToken", "--symbol", "DIF", "--initial-supply", "1000", "--with-test", "--seed", "7"}
+	require.NoError(t, executeGenerate(t, append(args, "--out", prev)...))
+	contract := filepath.Join(prev, "contracts", "DiffToken.sol")
+	before, err := os.ReadFile(contract)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	require.NoError(t, executeGenerate(t, append(args, "--diff-against", prev, "--pausable")...))
+
+	assert.Contains(t, out.String(), "--- "+contract+" (previous)")
+	assert.Contains(t, out.String(), "+++ "+contract+" (generated)")
+	assert.Contains(t, out.String(), "+    function pause() external onlyOwner {")
+	assert.Contains(t, out.String(), "+    function unpause() external onlyOwner {")
+	assert.Contains(t, out.String(), "2 file(s) changed against "+prev+" (nothing written)")
+
+	after, err := os.ReadFile(contract)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "--diff-against must not write files")
+
+	out.Reset()
+	require.NoError(t, executeGenerate(t, append(args, "--diff-against", prev)...))
+	assert.Contains(t, out.String(), "✅ No changes against "+prev)
+	assert.NotContains(t, out.String(), "@@")
+}
+
+func TestGenerate_ShowDiffPrintsChangesBeforeOverwriting(t *testing.T) {
+	root := t.TempDir()
+	args := []string{"--name", "ShowToken", "--symbol", "SHW", "--initial-supply", "1000", "--seed", "7", "--out", root}
+	require.NoError(t, executeGenerate(t, args...))
+	contract := filepath.Join(root, "contracts", "ShowToken.sol")
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	require.NoError(t, executeGenerate(t, append(args, "--show-diff", "--burnable", "--with-test")...))
+
+	assert.Contains(t, out.String(), "--- "+contract+" (existing)")
+	assert.Contains(t, out.String(), "+++ "+contract+" (generated)")
+	assert.Contains(t, out.String(), "+import \"@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol\";")
+	// New files are written without a diff.
+	assert.NotContains(t, out.String(), "--- "+filepath.Join(root, "test", "ShowToken.test.js"))
+
+	written, err := os.ReadFile(contract)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "ERC20Burnable")
+}
+
+func TestGenerate_ShowDiffRejectsDiffAgainst(t *testing.T) {
+	err := executeGenerate(t, "--name", "ShowToken", "--symbol", "SHW", "--show-diff", "--diff-against", t.TempDir())
+	require.ErrorContains(t, err, "--show-diff prints diffs while overwriting")
+}
+
+func TestGenerate_DiffAgainstRejectsCheck(t *testing.T) {
+	err := executeGenerate(t, "--name", "DiffToken", "--symbol", "DIF", "--diff-against", t.TempDir(), "--check", "--seed", "1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--diff-against cannot be combined with --check")
+}
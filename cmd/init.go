@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+const starterConfigName = "token.yaml"
+
+// starterConfig is written by `erc20gen init`. Keys are `generate` flag
+// names. Optional keys stay commented out: any key present in the file
+// counts as set and would stop --preset from filling it in.
+const starterConfig = `# erc20gen token config — use with: erc20gen generate --config token.yaml
+#
+# Every key is a "generate" flag name. Precedence:
+#   flag > environment variable > config file > preset > default
+# Uncomment a key to set it; commented keys show the default.
+
+# ─── Token ────────────────────────────────────────────────────────────────────
+name: MyToken              # 1-64 letters, digits, spaces, "-" or "_"
+# display-name: ""         # on-chain name(), unicode allowed ("" = name)
+symbol: MTK                # 1-11 uppercase letters/digits
+# symbol-case: auto-upper  # auto-upper | strict (reject lowercase)
+decimals: 18               # 0-77 (above 18 warns)
+initial-supply: "1000000"  # whole tokens minted to the deployer ("" = none)
+# max-supply: ""           # hard cap in whole tokens ("" = uncapped)
+# fixed-supply: false      # cap = initial supply, no minting
+# initial-holder: ""       # address minted the initial supply ("" = deployer)
+# expose-params: false     # initialSupply/maxSupply public immutables
+
+# ─── Features ─────────────────────────────────────────────────────────────────
+# mintable: false          # access-controlled mint()
+# mint-schedule: none      # none | linear (requires mintable)
+# emission-rate: ""        # linear: whole tokens mintable per second
+# emission-start: 0        # linear: unix timestamp accrual starts from
+# burnable: false          # holders burn their own tokens
+# admin-burn: false        # access-controlled burnFrom without allowance
+# with-locks: false        # admin lock() of part of a balance until a release time
+# with-merkle-claim: false # claim(amount, proof) against merkle-root, once per account
+# merkle-root: ""          # 0x-prefixed 32-byte root of the (account, amount) tree
+# with-metadata: false     # ERC-7572 contractURI() with an admin setter
+# metadata-uri: ""         # initial contractURI (http(s):// or ipfs://)
+# with-burn-rebase: false  # admin applyBurnRebase(bps) shrinking every balance
+# burn-rebase-max-bps: 1000 # largest single burn rebase, in basis points
+# reject-self-transfer: false # revert transfers and mints to the token's own address
+# reentrancy-guard: false  # nonReentrant on mint, burnFrom, bridge and claim
+# pausable: false          # emergency pause()/unpause()
+# start-paused: false      # deploy paused (requires pausable)
+# permit: false            # EIP-2612 gasless approvals
+# snapshot: false          # balance snapshots (OpenZeppelin v4)
+# votes: false             # ERC20Votes delegation
+# clock-mode: blocknumber  # votes clock: blocknumber | timestamp
+# with-events: true        # events for admin burns and scheduled mints
+# check-ticker: false      # warn if symbol matches a well-known token
+
+# ─── Access & upgrades ────────────────────────────────────────────────────────
+access: ownable            # ownable | roles | none
+# admin-address: ""        # roles admin passed by the deploy scripts (default: deployer)
+upgradeable: none          # none | uups | transparent
+# preset: ""               # stablecoin | governance | meme | utility | immutable
+
+# ─── Metadata ─────────────────────────────────────────────────────────────────
+license: MIT               # SPDX license identifier
+# title: ""                # NatSpec @title (default: name)
+# author: ""               # NatSpec @author
+# notice: ""               # NatSpec @notice
+# oz-version: "5"          # OpenZeppelin Contracts major version: 4 | 5
+# custom-errors: true      # custom errors vs require strings (default: on for v5, off for v4)
+# guard-style: ""          # require | if-revert ("" = follows custom-errors)
+# solidity-version: ^0.8.24
+# evm-version: ""          # london | paris | shanghai | cancun | prague ("" = solc default)
+
+# ─── Output ───────────────────────────────────────────────────────────────────
+interactive: false         # config-file runs skip the prompts
+out: .                     # project root for generated files
+layout: hardhat            # hardhat | foundry | flat
+with-deploy: true          # Hardhat deploy script
+# deploy-style: ethers     # ethers | hardhat-deploy (writes deploy/)
+with-test: true            # Hardhat test skeleton
+# test-style: ethers-js    # ethers-js | viem-ts
+# with-abi: false          # <Name>.abi.json
+# with-readme: false       # project README.md
+# with-ci: false           # GitHub Actions compile + test workflow
+# with-env: false          # .env.example with deploy key, RPC URL, explorer key
+# with-typechain: false    # typechain.config.js hardhat.config.js fragment
+# network: ""              # mainnet | sepolia | polygon | arbitrum | custom
+# file-mode: "0640"        # quote octal modes so YAML keeps them as strings
+# dir-mode: "0750"
+`
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented starter " + starterConfigName + " for config-file workflows",
+	Args:  cobra.NoArgs,
+	RunE:  runInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().Bool("force", false, "Overwrite an existing "+starterConfigName)
+	initCmd.Flags().String("out", ".", "Directory to write "+starterConfigName+" into")
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	dir, _ := cmd.Flags().GetString("out")
+	path := filepath.Join(dir, starterConfigName)
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0o640) // #nosec G302 G304 -- user-chosen output path
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists — pass --force to overwrite", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := f.WriteString(starterConfig); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	logger.Info("✅ Config written: " + path)
+	logger.Info(fmt.Sprintf("   Edit it, then run: %s generate --config %s", appName, path))
+	return nil
+}
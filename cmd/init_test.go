@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeInit(t *testing.T, args ...string) error {
+	t.Helper()
+	for _, name := range []string{"force", "out"} {
+		f := initCmd.Flags().Lookup(name)
+		_ = f.Value.Set(f.DefValue)
+		f.Changed = false
+	}
+	rootCmd.SetArgs(append([]string{"init"}, args...))
+	return rootCmd.Execute()
+}
+
+func TestInit_StarterConfigRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, executeInit(t, "--out", dir))
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(dir, starterConfigName))
+	require.NoError(t, v.ReadInConfig())
+	keys := v.AllKeys()
+	for _, m := range regexp.MustCompile(`(?m)^# ([a-z-]+):`).FindAllStringSubmatch(starterConfig, -1) {
+		keys = append(keys, m[1]) // commented-out keys must be real flags too
+	}
+	for _, key := range keys {
+		assert.NotNil(t, generateCmd.Flags().Lookup(key), "%s is not a generate flag", key)
+	}
+
+	resetGenerateFlags()
+	require.NoError(t, applyConfigDefaults(generateCmd, v))
+	cfg, err := buildConfigFromFlags(generateCmd)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "MyToken", cfg.Name)
+	assert.Equal(t, "MTK", cfg.Symbol)
+	assert.Equal(t, uint8(18), cfg.Decimals)
+	assert.Equal(t, "1000000", cfg.InitialSupply)
+	assert.Equal(t, config.AccessOwnable, cfg.AccessControl)
+	assert.Equal(t, config.UpgradeNone, cfg.Upgradeable)
+	assert.True(t, cfg.WithDeploy)
+	assert.True(t, cfg.WithTest)
+	interactive, _ := generateCmd.Flags().GetBool("interactive")
+	assert.False(t, interactive)
+}
+
+func TestInit_RefusesToOverwriteWithoutForce(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, starterConfigName)
+	require.NoError(t, os.WriteFile(path, []byte("name: Mine\n"), 0o600))
+
+	err := executeInit(t, "--out", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, "name: Mine\n", string(data))
+
+	require.NoError(t, executeInit(t, "--out", dir, "--force"))
+	data, _ = os.ReadFile(path)
+	assert.Equal(t, starterConfig, string(data))
+}
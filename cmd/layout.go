@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/Zubimendi/erc20gen/pkg/erc20gen"
+)
+
+// outputPaths holds the destination of every generated file.
+type outputPaths struct {
+	Contract   string
+	Governor   string
+	Deploy     string
+	Test       string
+	Invariants string
+	ABI        string
+	CI         string
+	Env        string
+	Typechain  string
+	Compiler   string
+	Foundry    string
+	Readme     string
+}
+
+// resolvePaths computes where each generated file lands under root, using
+// the layout's project-relative paths (see erc20gen.LayoutPaths).
+func resolvePaths(cfg *config.TokenConfig, layout, root string) (outputPaths, error) {
+	rel, err := erc20gen.LayoutPaths(cfg, layout)
+	if err != nil {
+		return outputPaths{}, err
+	}
+	join := func(p string) string { return filepath.Join(root, filepath.FromSlash(p)) }
+	return outputPaths{
+		Contract:   join(rel.Contract),
+		Governor:   join(rel.Governor),
+		Deploy:     join(rel.Deploy),
+		Test:       join(rel.Test),
+		Invariants: join(rel.Invariants),
+		ABI:        join(rel.ABI),
+		CI:         join(rel.CI),
+		Env:        join(rel.Env),
+		Typechain:  join(rel.Typechain),
+		Compiler:   join(rel.Compiler),
+		Foundry:    join(rel.Foundry),
+		Readme:     join(rel.Readme),
+	}, nil
+}
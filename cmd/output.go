@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// artifactWriter receives every generated file, so the generate command
+// doesn't care whether output lands on disk or in an archive.
+type artifactWriter interface {
+	Write(path string, data []byte) error
+	Close() error
+}
+
+// dirWriter writes loose files, creating parent directories as needed.
+type dirWriter struct {
+	fileMode os.FileMode
+	dirMode  os.FileMode
+}
+
+func (w dirWriter) Write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), w.dirMode); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return os.WriteFile(path, data, w.fileMode)
+}
+
+func (dirWriter) Close() error { return nil }
+
+// zipWriter collects files into a zip archive; paths become entry names
+// relative to the archive root.
+type zipWriter struct {
+	f        *os.File
+	zw       *zip.Writer
+	fileMode os.FileMode
+}
+
+func newZipWriter(path string, fileMode, dirMode os.FileMode) (*zipWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode)
+	if err != nil {
+		return nil, err
+	}
+	return &zipWriter{f: f, zw: zip.NewWriter(f), fileMode: fileMode}, nil
+}
+
+func (w *zipWriter) Write(path string, data []byte) error {
+	hdr := &zip.FileHeader{Name: filepath.ToSlash(path), Method: zip.Deflate}
+	hdr.SetMode(w.fileMode)
+	entry, err := w.zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+func (w *zipWriter) Close() error {
+	if err := w.zw.Close(); err != nil {
+		_ = w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// checkWriter compares each file with the one already at its path instead
+// of writing it. Close fails with a unified diff of every file that is
+// missing or differs.
+type checkWriter struct {
+	diffs []string
+}
+
+func (w *checkWriter) Write(path string, data []byte) error {
+	diff, err := fileDiff(path, data, "committed")
+	if err != nil || diff == "" {
+		return err
+	}
+	w.diffs = append(w.diffs, diff)
+	return nil
+}
+
+func (w *checkWriter) Close() error {
+	if len(w.diffs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d generated file(s) differ from the committed files — regenerate and commit them:\n\n%s",
+		len(w.diffs), strings.Join(w.diffs, "\n"))
+}
+
+// diffWriter prints a unified diff of each file against the one already at
+// its path (a previous generation under --diff-against) instead of writing
+// it. Unlike checkWriter, differences are the expected outcome, not errors.
+type diffWriter struct {
+	w       io.Writer
+	changed int
+}
+
+func (w *diffWriter) Write(path string, data []byte) error {
+	diff, err := fileDiff(path, data, "previous")
+	if err != nil || diff == "" {
+		return err
+	}
+	w.changed++
+	_, err = io.WriteString(w.w, diff)
+	return err
+}
+
+func (w *diffWriter) Close() error { return nil }
+
+// showDiffWriter prints a unified diff of each file that already exists at
+// its path and changes, then lets the wrapped writer overwrite it.
+type showDiffWriter struct {
+	artifactWriter
+	w io.Writer
+}
+
+func (w *showDiffWriter) Write(path string, data []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		diff, err := fileDiff(path, data, "existing")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w.w, diff); err != nil {
+			return err
+		}
+	}
+	return w.artifactWriter.Write(path, data)
+}
+
+// fileDiff returns a unified diff from the file at path, labeled existing
+// (or "missing" when there is none), to data. It returns "" when they match.
+func fileDiff(path string, data []byte, existing string) (string, error) {
+	old, err := os.ReadFile(path) // #nosec G304 -- the generate target path
+	from := path + " (" + existing + ")"
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		from = path + " (missing)"
+	case err != nil:
+		return "", err
+	case bytes.Equal(old, data):
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(old)),
+		B:        difflib.SplitLines(string(data)),
+		FromFile: from,
+		ToFile:   path + " (generated)",
+		Context:  3,
+	})
+}
+
+// hookWriter runs a user command on each file after the wrapped writer
+// writes it. The command is split on whitespace and run directly, without
+// a shell, with the file path appended as its last argument.
+// A done ctx kills the running hook and fails every later write.
+type hookWriter struct {
+	artifactWriter
+	ctx  context.Context
+	argv []string
+}
+
+func newHookWriter(ctx context.Context, w artifactWriter, command string) (*hookWriter, error) {
+	argv := strings.Fields(command)
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("--post-hook: command is empty")
+	}
+	return &hookWriter{artifactWriter: w, ctx: ctx, argv: argv}, nil
+}
+
+func (w *hookWriter) Write(path string, data []byte) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	if err := w.artifactWriter.Write(path, data); err != nil {
+		return err
+	}
+	args := append(append([]string{}, w.argv[1:]...), path)
+	out, err := exec.CommandContext(w.ctx, w.argv[0], args...).CombinedOutput() // #nosec G204 -- the user's own --post-hook command, no shell
+	if ctxErr := w.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err != nil {
+		msg := fmt.Sprintf("post-hook %q failed on %s: %s", strings.Join(w.argv, " "), path, err)
+		if s := strings.TrimSpace(string(out)); s != "" {
+			msg += "\n" + s
+		}
+		return errors.New(msg)
+	}
+	return nil
+}
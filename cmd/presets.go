@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// presets are curated flag combinations for common token types. A preset
+// only fills flags the user left unset, so explicit flags always win.
+var presets = map[string]map[string]string{
+	"stablecoin": {
+		"decimals": "6",
+		"mintable": "true",
+		"pausable": "true",
+		"access":   "roles",
+	},
+	"governance": {
+		"votes":    "true",
+		"permit":   "true",
+		"snapshot": "true",
+		"access":   "ownable",
+	},
+	"meme": {
+		"initial-supply": "1000000000",
+		"burnable":       "true",
+		"access":         "none",
+	},
+	"utility": {
+		"mintable": "true",
+		"burnable": "true",
+		"access":   "ownable",
+	},
+}
+
+// presetNames returns every accepted --preset value: the flag presets above
+// plus the stricter presets implemented in the config package.
+func presetNames() []string {
+	names := config.PresetNames()
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyPresetDefaults sets every flag of the named preset that the user did
+// not pass explicitly. Config-level presets (e.g. immutable) are applied
+// later by config.ApplyPreset and are left untouched here.
+func applyPresetDefaults(cmd *cobra.Command, name string) error {
+	if name == "" {
+		return nil
+	}
+	values, ok := presets[name]
+	if !ok {
+		for _, n := range config.PresetNames() {
+			if n == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown preset %q — must be one of: %s", name, strings.Join(presetNames(), ", "))
+	}
+	for flag, value := range values {
+		if cmd.Flags().Changed(flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return fmt.Errorf("preset %s: %s: %w", name, flag, err)
+		}
+	}
+	return nil
+}
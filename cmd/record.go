@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// recordHeader names the columns of a --record CSV.
+var recordHeader = []string{"timestamp", "name", "symbol", "decimals", "features", "access_control", "config_sha256"}
+
+// appendRecord appends one row describing cfg to the CSV at path, writing
+// the header first when the file is new or empty. Features are separated
+// by semicolons so the row stays one column per field.
+func appendRecord(path string, cfg *config.TokenConfig, at time.Time, mode os.FileMode) error {
+	hash, err := cfg.ConfigHash()
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, mode) // #nosec G304 -- the user's own --record path
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		_ = w.Write(recordHeader)
+	}
+	_ = w.Write([]string{
+		at.UTC().Format(time.RFC3339),
+		cfg.Name,
+		cfg.Symbol,
+		strconv.Itoa(int(cfg.Decimals)),
+		strings.Join(cfg.EnabledFeatures(), ";"),
+		string(cfg.AccessControl),
+		hash,
+	})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_RecordAppendsCSVRows(t *testing.T) {
+	record := filepath.Join(t.TempDir(), "tokens.csv")
+	require.NoError(t, executeGenerate(t, "--name", "First", "--symbol", "ONE", "--initial-supply", "1000", "--out", t.TempDir(), "--record", record))
+	require.NoError(t, executeGenerate(t, "--name", "Second", "--symbol", "TWO", "--decimals", "6", "--initial-supply", "1000", "--mintable", "--max-supply", "5000", "--pausable", "--access", "roles", "--out", t.TempDir(), "--record", record))
+
+	f, err := os.Open(record)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "one header and two data rows")
+
+	assert.Equal(t, recordHeader, rows[0])
+	for _, row := range rows[1:] {
+		require.Len(t, row, len(recordHeader))
+		_, err := time.Parse(time.RFC3339, row[0])
+		assert.NoError(t, err, "timestamp column")
+		assert.Regexp(t, `^[0-9a-f]{64}$`, row[6], "config hash column")
+	}
+	assert.Equal(t, []string{"First", "ONE", "18", "", "ownable"}, rows[1][1:6])
+	assert.Equal(t, []string{"Second", "TWO", "6", "Mintable;Pausable", "roles"}, rows[2][1:6])
+	assert.NotEqual(t, rows[1][6], rows[2][6])
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 
+	"github.com/Zubimendi/erc20gen/internal/generator"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
 	appName    = "erc20gen"
-	appVersion = "1.0.0"
+	appVersion = generator.Version
 )
 
 var cfgFile string
@@ -32,11 +36,19 @@ Pausable, Permit (EIP-2612), Snapshots, and Capped supply.
 
 Built with security-first principles. No paid services required.
 `,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		verbosity, _ := cmd.Flags().GetCount("verbose")
+		logger = newLogger(cmd.OutOrStdout(), verbosity)
+	},
 }
 
-// Execute is the entry point called from main.
+// Execute is the entry point called from main. Ctrl-C cancels the command
+// context, which stops generation and kills a running compiler or post-hook.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -47,6 +59,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default: $HOME/.erc20gen.yaml)")
 	rootCmd.PersistentFlags().Bool("no-color", false, "disable colored output")
 	_ = viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
+	rootCmd.PersistentFlags().CountP("verbose", "v", "log the resolved config, templates rendered, and files written (repeatable)")
 }
 
 func initConfig() {
@@ -59,6 +72,9 @@ func initConfig() {
 		viper.SetConfigType("yaml")
 		viper.SetConfigName(".erc20gen")
 	}
+	// ERC20GEN_INITIAL_SUPPLY → "initial-supply"
+	viper.SetEnvPrefix("ERC20GEN")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 	_ = viper.ReadInConfig()
-}
\ No newline at end of file
+}
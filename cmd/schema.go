@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/Zubimendi/erc20gen/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema (draft 2020-12) for the token config file",
+	Long: `Schema prints a JSON Schema describing every config-file key: its type,
+constraints (symbol pattern, decimals range, access enum, ...), and default.
+Use it to validate config files or to build a UI on top of erc20gen.
+
+Example:
+  erc20gen schema > erc20gen.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := schema.Generate(generateCmd.Flags())
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(s)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
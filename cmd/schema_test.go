@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_IsValidJSONWithAccessEnum(t *testing.T) {
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+	rootCmd.SetArgs([]string{"schema"})
+	require.NoError(t, rootCmd.Execute())
+
+	require.True(t, json.Valid(out.Bytes()), "schema must be valid JSON")
+	var s struct {
+		Schema     string `json:"$schema"`
+		Type       string `json:"type"`
+		Required   []string
+		Properties map[string]struct {
+			Type    string
+			Enum    []string
+			Pattern string
+			Minimum *int64
+			Maximum *int64
+			Default interface{}
+		}
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &s))
+
+	assert.Equal(t, schema.Draft, s.Schema)
+	assert.Equal(t, "object", s.Type)
+	assert.ElementsMatch(t, []string{"name", "symbol"}, s.Required)
+
+	access := s.Properties["access"]
+	assert.Equal(t, "string", access.Type)
+	assert.Equal(t, []string{"ownable", "roles", "none"}, access.Enum)
+	assert.Equal(t, "ownable", access.Default)
+
+	assert.Equal(t, `^[A-Z0-9]{1,11}$`, s.Properties["symbol"].Pattern)
+	decimals := s.Properties["decimals"]
+	assert.Equal(t, "integer", decimals.Type)
+	require.NotNil(t, decimals.Maximum)
+	assert.EqualValues(t, 77, *decimals.Maximum)
+	assert.EqualValues(t, 18, decimals.Default)
+	assert.Equal(t, "boolean", s.Properties["mintable"].Type)
+	assert.Equal(t, "array", s.Properties["allocation"].Type)
+}
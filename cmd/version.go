@@ -16,4 +16,4 @@ var versionCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
-}
\ No newline at end of file
+}
@@ -0,0 +1,282 @@
+// Package abi assembles a curated Ethereum ABI for a generated token from
+// per-feature fragments, so frontends can integrate without compiling.
+package abi
+
+import (
+	"encoding/json"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// Param is a single function/event input or output.
+type Param struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// Fragment is one ABI entry: a constructor, function, or event.
+type Fragment struct {
+	Type            string  `json:"type"`
+	Name            string  `json:"name,omitempty"`
+	Inputs          []Param `json:"inputs"`
+	Outputs         []Param `json:"outputs,omitempty"`
+	StateMutability string  `json:"stateMutability,omitempty"`
+}
+
+func view(name string, inputs []Param, outType string) Fragment {
+	return Fragment{Type: "function", Name: name, Inputs: inputs, Outputs: []Param{{Name: "", Type: outType}}, StateMutability: "view"}
+}
+
+func nonpayable(name string, inputs ...Param) Fragment {
+	return Fragment{Type: "function", Name: name, Inputs: inputs, StateMutability: "nonpayable"}
+}
+
+func event(name string, inputs ...Param) Fragment {
+	return Fragment{Type: "event", Name: name, Inputs: inputs}
+}
+
+func p(name, typ string) Param       { return Param{Name: name, Type: typ} }
+func indexed(name, typ string) Param { return Param{Name: name, Type: typ, Indexed: true} }
+func params(ps ...Param) []Param     { return ps }
+
+// ABIFragments returns the ABI entries exposed by a token generated from cfg.
+func ABIFragments(cfg *config.TokenConfig) []Fragment {
+	var frags []Fragment
+
+	// Constructor / initializer
+	var adminParam []Param
+	if cfg.NeedsOwnable() {
+		adminParam = params(p("initialOwner", "address"))
+	} else if cfg.NeedsRoles() {
+		adminParam = params(p("defaultAdmin", "address"))
+	}
+	if cfg.IsUpgradeable() {
+		frags = append(frags,
+			Fragment{Type: "constructor", Inputs: []Param{}, StateMutability: "nonpayable"},
+			nonpayable("initialize", adminParam...),
+		)
+	} else {
+		inputs := append([]Param{}, adminParam...)
+		for _, cp := range cfg.CtorParams() {
+			inputs = append(inputs, p(cp.Name+"_", cp.Type))
+		}
+		frags = append(frags, Fragment{Type: "constructor", Inputs: inputs, StateMutability: "nonpayable"})
+		for _, cp := range cfg.CtorParams() {
+			frags = append(frags, view(cp.Name, nil, cp.Type))
+		}
+		if cfg.ExposesParams() && cfg.InitialSupply != "" {
+			frags = append(frags, view("initialSupply", nil, "uint256"))
+		}
+		if cfg.ExposesParams() && cfg.MaxSupply != "" {
+			frags = append(frags, view("maxSupply", nil, "uint256"))
+		}
+	}
+
+	// Core ERC-20
+	frags = append(frags,
+		view("name", nil, "string"),
+		view("symbol", nil, "string"),
+		view("decimals", nil, "uint8"),
+		view("totalSupply", nil, "uint256"),
+		view("balanceOf", params(p("account", "address")), "uint256"),
+		view("allowance", params(p("owner", "address"), p("spender", "address")), "uint256"),
+		withBoolOutput(nonpayable("transfer", p("to", "address"), p("value", "uint256"))),
+		withBoolOutput(nonpayable("approve", p("spender", "address"), p("value", "uint256"))),
+		withBoolOutput(nonpayable("transferFrom", p("from", "address"), p("to", "address"), p("value", "uint256"))),
+		event("Transfer", indexed("from", "address"), indexed("to", "address"), p("value", "uint256")),
+		event("Approval", indexed("owner", "address"), indexed("spender", "address"), p("value", "uint256")),
+	)
+
+	if cfg.Mintable {
+		frags = append(frags, nonpayable("mint", p("to", "address"), p("amount", "uint256")))
+	}
+	if cfg.EmitCapReached {
+		frags = append(frags, event("CapReached"))
+	}
+	if cfg.Airdrop {
+		frags = append(frags, nonpayable("batchTransfer", p("recipients", "address[]"), p("amounts", "uint256[]")))
+	}
+	if cfg.MerkleClaim {
+		frags = append(frags,
+			view("MERKLE_ROOT", nil, "bytes32"),
+			view("claimed", params(p("", "address")), "bool"),
+			nonpayable("claim", p("amount", "uint256"), p("proof", "bytes32[]")),
+		)
+		if cfg.EmitsEvents() {
+			frags = append(frags, event("Claimed", indexed("account", "address"), p("amount", "uint256")))
+		}
+	}
+	if cfg.Metadata {
+		frags = append(frags,
+			view("contractURI", nil, "string"),
+			nonpayable("setContractURI", p("newURI", "string")),
+			event("ContractURIUpdated"),
+		)
+	}
+	if cfg.BurnRebase {
+		frags = append(frags,
+			view("MAX_BURN_REBASE_BPS", nil, "uint256"),
+			view("rebaseIndex", nil, "uint256"),
+			nonpayable("applyBurnRebase", p("bps", "uint256")),
+		)
+		if cfg.EmitsEvents() {
+			frags = append(frags, event("BurnRebase", indexed("operator", "address"), p("bps", "uint256"), p("rebaseIndex", "uint256")))
+		}
+	}
+	if cfg.HasBridge() {
+		frags = append(frags,
+			view("BRIDGE", nil, "address"),
+			nonpayable("mint", p("to", "address"), p("amount", "uint256")),
+			nonpayable("burn", p("from", "address"), p("amount", "uint256")),
+		)
+	}
+	if cfg.HasMintSchedule() {
+		frags = append(frags,
+			view("EMISSION_RATE", nil, "uint256"),
+			view("EMISSION_START", nil, "uint256"),
+			view("scheduledMinted", nil, "uint256"),
+			view("mintableAmount", nil, "uint256"),
+		)
+		if cfg.EmitsEvents() {
+			frags = append(frags, event("ScheduledMint", indexed("to", "address"), p("amount", "uint256"), p("totalMinted", "uint256")))
+		}
+	}
+	if cfg.Burnable {
+		frags = append(frags,
+			nonpayable("burn", p("value", "uint256")),
+			nonpayable("burnFrom", p("account", "address"), p("value", "uint256")),
+		)
+	}
+	if cfg.AdminBurn {
+		frags = append(frags, nonpayable("burnFrom", p("from", "address"), p("amount", "uint256")))
+		if cfg.EmitsEvents() {
+			frags = append(frags, event("AdminBurned", indexed("operator", "address"), indexed("from", "address"), p("amount", "uint256")))
+		}
+	}
+	if cfg.Locks {
+		// Public getter of mapping(address => Lock): one output per struct field.
+		locks := view("locks", params(p("", "address")), "uint256")
+		locks.Outputs = []Param{p("amount", "uint256"), p("releaseTime", "uint64")}
+		frags = append(frags,
+			nonpayable("lock", p("account", "address"), p("amount", "uint256"), p("releaseTime", "uint64")),
+			view("lockedBalanceOf", params(p("account", "address")), "uint256"),
+			locks,
+		)
+		if cfg.EmitsEvents() {
+			frags = append(frags, event("BalanceLocked", indexed("operator", "address"), indexed("account", "address"), p("amount", "uint256"), p("releaseTime", "uint64")))
+		}
+	}
+	if cfg.Pausable {
+		frags = append(frags,
+			nonpayable("pause"),
+			nonpayable("unpause"),
+			view("paused", nil, "bool"),
+			event("Paused", p("account", "address")),
+			event("Unpaused", p("account", "address")),
+		)
+	}
+	if cfg.Permit {
+		frags = append(frags,
+			nonpayable("permit",
+				p("owner", "address"), p("spender", "address"), p("value", "uint256"),
+				p("deadline", "uint256"), p("v", "uint8"), p("r", "bytes32"), p("s", "bytes32"),
+			),
+			view("nonces", params(p("owner", "address")), "uint256"),
+			view("DOMAIN_SEPARATOR", nil, "bytes32"),
+		)
+	}
+	if cfg.Snapshot {
+		snap := nonpayable("snapshot")
+		snap.Outputs = []Param{p("", "uint256")}
+		frags = append(frags,
+			snap,
+			view("balanceOfAt", params(p("account", "address"), p("snapshotId", "uint256")), "uint256"),
+			view("totalSupplyAt", params(p("snapshotId", "uint256")), "uint256"),
+			event("Snapshot", p("id", "uint256")),
+		)
+	}
+	if cfg.Votes {
+		frags = append(frags,
+			view("delegates", params(p("account", "address")), "address"),
+			view("getVotes", params(p("account", "address")), "uint256"),
+			view("getPastVotes", params(p("account", "address"), p("timepoint", "uint256")), "uint256"),
+			view("getPastTotalSupply", params(p("timepoint", "uint256")), "uint256"),
+			view("clock", nil, "uint48"),
+			view("CLOCK_MODE", nil, "string"),
+			nonpayable("delegate", p("delegatee", "address")),
+			nonpayable("delegateBySig",
+				p("delegatee", "address"), p("nonce", "uint256"), p("expiry", "uint256"),
+				p("v", "uint8"), p("r", "bytes32"), p("s", "bytes32"),
+			),
+			event("DelegateChanged", indexed("delegator", "address"), indexed("fromDelegate", "address"), indexed("toDelegate", "address")),
+			event("DelegateVotesChanged", indexed("delegate", "address"), p("previousVotes", "uint256"), p("newVotes", "uint256")),
+		)
+	}
+	if cfg.NeedsEIP712() {
+		// Votes inherits Nonces for delegateBySig even without Permit.
+		frags = append(frags, view("nonces", params(p("owner", "address")), "uint256"))
+	}
+	if cfg.MaxSupply != "" {
+		frags = append(frags, view("cap", nil, "uint256"))
+	}
+	if cfg.NeedsOwnable() {
+		frags = append(frags,
+			view("owner", nil, "address"),
+			nonpayable("transferOwnership", p("newOwner", "address")),
+			nonpayable("renounceOwnership"),
+			event("OwnershipTransferred", indexed("previousOwner", "address"), indexed("newOwner", "address")),
+		)
+	}
+	if cfg.NeedsRoles() {
+		frags = append(frags,
+			view("DEFAULT_ADMIN_ROLE", nil, "bytes32"),
+			view("MINTER_ROLE", nil, "bytes32"),
+			view("PAUSER_ROLE", nil, "bytes32"),
+			view("SNAPSHOT_ROLE", nil, "bytes32"),
+		)
+		if cfg.AdminBurn {
+			frags = append(frags, view("BURNER_ROLE", nil, "bytes32"))
+		}
+		if cfg.Locks {
+			frags = append(frags, view("LOCKER_ROLE", nil, "bytes32"))
+		}
+		frags = append(frags,
+			view("hasRole", params(p("role", "bytes32"), p("account", "address")), "bool"),
+			view("getRoleAdmin", params(p("role", "bytes32")), "bytes32"),
+			view("supportsInterface", params(p("interfaceId", "bytes4")), "bool"),
+			nonpayable("grantRole", p("role", "bytes32"), p("account", "address")),
+			nonpayable("revokeRole", p("role", "bytes32"), p("account", "address")),
+			nonpayable("renounceRole", p("role", "bytes32"), p("callerConfirmation", "address")),
+			event("RoleGranted", indexed("role", "bytes32"), indexed("account", "address"), indexed("sender", "address")),
+			event("RoleRevoked", indexed("role", "bytes32"), indexed("account", "address"), indexed("sender", "address")),
+			event("RoleAdminChanged", indexed("role", "bytes32"), indexed("previousAdminRole", "bytes32"), indexed("newAdminRole", "bytes32")),
+		)
+	}
+	if cfg.IsUUPS() {
+		upgrade := nonpayable("upgradeToAndCall", p("newImplementation", "address"), p("data", "bytes"))
+		upgrade.StateMutability = "payable"
+		frags = append(frags,
+			upgrade,
+			view("proxiableUUID", nil, "bytes32"),
+			event("Upgraded", indexed("implementation", "address")),
+		)
+	}
+
+	for i := range frags {
+		if frags[i].Inputs == nil {
+			frags[i].Inputs = []Param{}
+		}
+	}
+	return frags
+}
+
+// JSON renders the token's ABI as indented JSON.
+func JSON(cfg *config.TokenConfig) ([]byte, error) {
+	return json.MarshalIndent(ABIFragments(cfg), "", "  ")
+}
+
+func withBoolOutput(f Fragment) Fragment {
+	f.Outputs = []Param{p("", "bool")}
+	return f
+}
@@ -0,0 +1,126 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/abi"
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func baseConfig() *config.TokenConfig {
+	return &config.TokenConfig{
+		Name:          "TestToken",
+		Symbol:        "TST",
+		Decimals:      18,
+		AccessControl: config.AccessOwnable,
+	}
+}
+
+func names(t *testing.T, cfg *config.TokenConfig) map[string]bool {
+	t.Helper()
+	raw, err := abi.JSON(cfg)
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(raw, &entries), "ABI must be valid JSON")
+
+	seen := map[string]bool{}
+	for _, e := range entries {
+		require.Contains(t, e, "type")
+		require.Contains(t, e, "inputs")
+		if name, ok := e["name"].(string); ok {
+			seen[name] = true
+		}
+	}
+	return seen
+}
+
+func TestJSON_BaseTokenHasERC20Surface(t *testing.T) {
+	seen := names(t, baseConfig())
+	for _, fn := range []string{"name", "symbol", "decimals", "totalSupply", "balanceOf", "transfer", "approve", "transferFrom", "allowance", "Transfer", "Approval", "owner"} {
+		assert.True(t, seen[fn], "expected %q in ABI", fn)
+	}
+	assert.False(t, seen["mint"])
+	assert.False(t, seen["pause"])
+}
+
+func TestJSON_FeatureFragments(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.Pausable = true
+	seen := names(t, cfg)
+	assert.True(t, seen["mint"])
+	assert.True(t, seen["pause"])
+	assert.True(t, seen["unpause"])
+	assert.True(t, seen["Paused"])
+}
+
+func TestJSON_RolesReplaceOwnable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessRoles
+	seen := names(t, cfg)
+	assert.True(t, seen["hasRole"])
+	assert.True(t, seen["MINTER_ROLE"])
+	assert.False(t, seen["owner"])
+}
+
+func TestABIFragments_ConstructorMatchesAccessControl(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessNone
+	frags := abi.ABIFragments(cfg)
+	require.Equal(t, "constructor", frags[0].Type)
+	assert.Empty(t, frags[0].Inputs)
+
+	cfg.AccessControl = config.AccessOwnable
+	frags = abi.ABIFragments(cfg)
+	require.Len(t, frags[0].Inputs, 1)
+	assert.Equal(t, "initialOwner", frags[0].Inputs[0].Name)
+}
+
+func TestJSON_ExposeParamsGetters(t *testing.T) {
+	cfg := baseConfig()
+	cfg.InitialSupply = "1000"
+	cfg.MaxSupply = "5000"
+	assert.False(t, names(t, cfg)["maxSupply"])
+
+	cfg.ExposeParams = true
+	seen := names(t, cfg)
+	assert.True(t, seen["initialSupply"])
+	assert.True(t, seen["maxSupply"])
+}
+
+func TestJSON_MerkleClaim(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MerkleClaim = true
+	seen := names(t, cfg)
+	for _, n := range []string{"MERKLE_ROOT", "claimed", "claim", "Claimed"} {
+		assert.True(t, seen[n], n)
+	}
+
+	cfg.OmitEvents = true
+	assert.False(t, names(t, cfg)["Claimed"])
+}
+
+func TestJSON_Metadata(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Metadata = true
+	seen := names(t, cfg)
+	for _, n := range []string{"contractURI", "setContractURI", "ContractURIUpdated"} {
+		assert.True(t, seen[n], n)
+	}
+}
+
+func TestJSON_BurnRebase(t *testing.T) {
+	cfg := baseConfig()
+	cfg.BurnRebase = true
+	seen := names(t, cfg)
+	for _, n := range []string{"MAX_BURN_REBASE_BPS", "rebaseIndex", "applyBurnRebase", "BurnRebase"} {
+		assert.True(t, seen[n], n)
+	}
+
+	cfg.OmitEvents = true
+	assert.False(t, names(t, cfg)["BurnRebase"])
+}
@@ -0,0 +1,130 @@
+// Package audit grades a token config against a best-practices matrix
+// before any code is generated. Each rule inspects the config and, when it
+// fires, contributes one Finding.
+package audit
+
+import (
+	"sort"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// Severity ranks how much attention a finding deserves.
+type Severity string
+
+const (
+	SeverityHigh   Severity = "high"
+	SeverityMedium Severity = "medium"
+	SeverityLow    Severity = "low"
+	SeverityInfo   Severity = "info"
+)
+
+// rank orders severities from most to least urgent.
+var rank = map[Severity]int{SeverityHigh: 0, SeverityMedium: 1, SeverityLow: 2, SeverityInfo: 3}
+
+// Finding is one observation about a config.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Rule is one entry of the best-practices matrix. Applies reports whether
+// the rule fires for a config.
+type Rule struct {
+	ID       string
+	Severity Severity
+	Message  string
+	Applies  func(c *config.TokenConfig) bool
+}
+
+// Rules is the best-practices matrix, in report order within a severity.
+var Rules = []Rule{
+	{
+		ID:       "uncapped-mint",
+		Severity: SeverityHigh,
+		Message:  "Mintable without a cap: unlimited inflation risk — set --max-supply",
+		Applies:  func(c *config.TokenConfig) bool { return c.Mintable && c.MaxSupply == "" },
+	},
+	{
+		ID:       "admin-burn",
+		Severity: SeverityHigh,
+		Message:  "Admin burn lets the admin destroy any holder's balance without an allowance",
+		Applies:  func(c *config.TokenConfig) bool { return c.AdminBurn },
+	},
+	{
+		ID:       "admin-locks",
+		Severity: SeverityMedium,
+		Message:  "Balance locks let the admin freeze any holder's tokens until a release time of its choosing",
+		Applies:  func(c *config.TokenConfig) bool { return c.Locks },
+	},
+	{
+		ID:       "uncapped-bridge",
+		Severity: SeverityMedium,
+		Message:  "Bridge mint is uncapped: a compromised BRIDGE can inflate supply without limit",
+		Applies:  func(c *config.TokenConfig) bool { return c.HasBridge() && c.MaxSupply == "" },
+	},
+	{
+		ID:       "no-admin-events",
+		Severity: SeverityMedium,
+		Message:  "No events on admin actions: admin burns and scheduled mints are hard to monitor off-chain",
+		Applies: func(c *config.TokenConfig) bool {
+			return !c.EmitsEvents() && (c.AdminBurn || c.HasMintSchedule())
+		},
+	},
+	{
+		ID:       "upgradeable",
+		Severity: SeverityMedium,
+		Message:  "Upgradeable proxy: the admin can replace the contract logic — put upgrades behind a timelock or multisig",
+		Applies:  func(c *config.TokenConfig) bool { return c.IsUpgradeable() },
+	},
+	{
+		ID:       "pausable",
+		Severity: SeverityLow,
+		Message:  "Pausable adds centralization: the admin can freeze every transfer",
+		Applies:  func(c *config.TokenConfig) bool { return c.Pausable },
+	},
+	{
+		ID:       "single-owner",
+		Severity: SeverityLow,
+		Message:  "Single-key ownership guards privileged functions — transfer ownership to a multisig after deployment",
+		Applies: func(c *config.TokenConfig) bool {
+			return c.NeedsOwnable() && (c.Mintable || c.Pausable || c.AdminBurn || c.Locks || c.IsUUPS())
+		},
+	},
+	{
+		ID:       "start-paused",
+		Severity: SeverityInfo,
+		Message:  "Token deploys paused: holders cannot transfer until the admin calls unpause()",
+		Applies:  func(c *config.TokenConfig) bool { return c.StartPaused },
+	},
+	{
+		ID:       "no-supply",
+		Severity: SeverityInfo,
+		Message:  "No initial supply and no way to mint: the token will never have a balance",
+		Applies: func(c *config.TokenConfig) bool {
+			return c.InitialSupply == "" && !c.Mintable && !c.HasBridge()
+		},
+	},
+}
+
+// Run evaluates every rule against c, plus one low-severity finding per
+// config warning and deprecated OpenZeppelin pattern, most severe first.
+func Run(c *config.TokenConfig) []Finding {
+	var findings []Finding
+	for _, r := range Rules {
+		if r.Applies(c) {
+			findings = append(findings, Finding{Rule: r.ID, Severity: r.Severity, Message: r.Message})
+		}
+	}
+	for _, msg := range c.Warnings() {
+		findings = append(findings, Finding{Rule: "warning", Severity: SeverityLow, Message: msg})
+	}
+	for _, msg := range c.Deprecations() {
+		findings = append(findings, Finding{Rule: "deprecated", Severity: SeverityLow, Message: msg})
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return rank[findings[i].Severity] < rank[findings[j].Severity]
+	})
+	return findings
+}
@@ -0,0 +1,83 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/audit"
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func baseConfig() *config.TokenConfig {
+	return &config.TokenConfig{
+		Name:          "TestToken",
+		Symbol:        "TST",
+		Decimals:      18,
+		InitialSupply: "1000000",
+		AccessControl: config.AccessRoles,
+		OZVersion:     config.OZv5,
+	}
+}
+
+func rules(findings []audit.Finding) []string {
+	ids := make([]string, len(findings))
+	for i, f := range findings {
+		ids[i] = f.Rule
+	}
+	return ids
+}
+
+func TestRun_PlainTokenHasNoFindings(t *testing.T) {
+	assert.Empty(t, audit.Run(baseConfig()))
+}
+
+func TestRun_RuleFires(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		rule  string
+	}{
+		{"uncapped mint", func(c *config.TokenConfig) { c.Mintable = true }, "uncapped-mint"},
+		{"admin burn", func(c *config.TokenConfig) { c.AdminBurn = true }, "admin-burn"},
+		{"admin locks", func(c *config.TokenConfig) { c.Locks = true }, "admin-locks"},
+		{"uncapped bridge", func(c *config.TokenConfig) { c.BridgeMinter = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" }, "uncapped-bridge"},
+		{"no admin events", func(c *config.TokenConfig) { c.AdminBurn, c.OmitEvents = true, true }, "no-admin-events"},
+		{"upgradeable", func(c *config.TokenConfig) { c.Upgradeable = config.UpgradeUUPS }, "upgradeable"},
+		{"pausable", func(c *config.TokenConfig) { c.Pausable = true }, "pausable"},
+		{"single owner", func(c *config.TokenConfig) { c.AccessControl, c.Pausable = config.AccessOwnable, true }, "single-owner"},
+		{"start paused", func(c *config.TokenConfig) { c.Pausable, c.StartPaused = true, true }, "start-paused"},
+		{"no supply", func(c *config.TokenConfig) { c.InitialSupply = "" }, "no-supply"},
+		{"deprecated", func(c *config.TokenConfig) { c.Snapshot = true }, "deprecated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			tt.setup(cfg)
+			assert.Contains(t, rules(audit.Run(cfg)), tt.rule)
+		})
+	}
+}
+
+func TestRun_CapSilencesInflationFinding(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.MaxSupply = "2000000"
+	assert.NotContains(t, rules(audit.Run(cfg)), "uncapped-mint")
+}
+
+func TestRun_EventsSilenceAdminEventFinding(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AdminBurn = true
+	assert.NotContains(t, rules(audit.Run(cfg)), "no-admin-events")
+}
+
+func TestRun_MostSevereFirst(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Pausable = true
+	cfg.StartPaused = true
+	cfg.Mintable = true
+
+	findings := audit.Run(cfg)
+	assert.Equal(t, []string{"uncapped-mint", "pausable", "start-paused"}, rules(findings))
+	assert.Equal(t, audit.SeverityHigh, findings[0].Severity)
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strings"
+)
+
+var addressRe = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// ChecksumAddress returns addr in EIP-55 mixed-case form. solc rejects
+// address literals that are not checksummed, so every address rendered
+// into a contract goes through here. addr must match ^0x[0-9a-fA-F]{40}$.
+func ChecksumAddress(addr string) string {
+	lower := strings.ToLower(strings.TrimPrefix(addr, "0x"))
+	hash := keccak256([]byte(lower))
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+// validateAddress checks that s is a non-zero 20-byte hex address. Mixed
+// case input must carry a valid EIP-55 checksum, which catches typos.
+func validateAddress(s string) error {
+	if !addressRe.MatchString(s) {
+		return fmt.Errorf("%q is not a 0x-prefixed 20-byte hex address", s)
+	}
+	hex := s[2:]
+	if strings.Trim(hex, "0") == "" {
+		return fmt.Errorf("the zero address is not allowed")
+	}
+	if hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && s != ChecksumAddress(s) {
+		return fmt.Errorf("%q fails its EIP-55 checksum (expected %s)", s, ChecksumAddress(s))
+	}
+	return nil
+}
+
+// keccak256 is the original Keccak-256 (0x01 padding) used by Ethereum,
+// not NIST SHA3-256. The standard library only has the latter.
+func keccak256(data []byte) [32]byte {
+	const rate = 136
+	buf := make([]byte, len(data), len(data)+rate)
+	copy(buf, data)
+	buf = append(buf, 0x01)
+	for len(buf)%rate != 0 {
+		buf = append(buf, 0)
+	}
+	buf[len(buf)-1] |= 0x80
+
+	var st [25]uint64
+	for off := 0; off < len(buf); off += rate {
+		for i := 0; i < rate/8; i++ {
+			st[i] ^= binary.LittleEndian.Uint64(buf[off+8*i:])
+		}
+		keccakF1600(&st)
+	}
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[8*i:], st[i])
+	}
+	return out
+}
+
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var (
+	keccakRotc = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakPiln = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+func keccakF1600(st *[25]uint64) {
+	var bc [5]uint64
+	for round := 0; round < 24; round++ {
+		// θ
+		for i := 0; i < 5; i++ {
+			bc[i] = st[i] ^ st[i+5] ^ st[i+10] ^ st[i+15] ^ st[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ bits.RotateLeft64(bc[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				st[j+i] ^= t
+			}
+		}
+		// ρ and π
+		t := st[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiln[i]
+			t, st[j] = st[j], bits.RotateLeft64(t, keccakRotc[i])
+		}
+		// χ
+		for j := 0; j < 25; j += 5 {
+			copy(bc[:], st[j:j+5])
+			for i := 0; i < 5; i++ {
+				st[j+i] ^= ^bc[(i+1)%5] & bc[(i+2)%5]
+			}
+		}
+		// ι
+		st[0] ^= keccakRC[round]
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Allocation is a share of the genesis supply minted to one address.
+type Allocation struct {
+	Address string
+	Amount  string // whole tokens
+}
+
+// ParseAllocation parses "<address>=<amount>", e.g. "0xAb…=250000".
+func ParseAllocation(s string) (Allocation, error) {
+	addr, amount, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return Allocation{}, fmt.Errorf("%q must be \"<address>=<whole tokens>\"", s)
+	}
+	return Allocation{Address: strings.TrimSpace(addr), Amount: strings.TrimSpace(amount)}, nil
+}
+
+// ChecksumAddress returns the allocation's address in EIP-55 form.
+func (a Allocation) ChecksumAddress() string {
+	return ChecksumAddress(a.Address)
+}
+
+// validateAllocations checks every allocation and that together they mint
+// exactly InitialSupply.
+func (c *TokenConfig) validateAllocations(errs *ValidationError) {
+	if c.InitialHolder != "" {
+		errs.add("Allocations", "allocations conflict with an initial holder — give the holder an allocation instead")
+	}
+	if c.InitialSupply == "" {
+		errs.add("Allocations", "allocations require an initial supply to split")
+		return
+	}
+
+	sum := new(big.Int)
+	seen := make(map[string]bool)
+	valid := true
+	for _, a := range c.Allocations {
+		if err := validateAddress(a.Address); err != nil {
+			errs.add("Allocations", fmt.Sprintf("allocation %s: %s", a.Address, err))
+			valid = false
+			continue
+		}
+		if key := strings.ToLower(a.Address); seen[key] {
+			errs.add("Allocations", fmt.Sprintf("allocation %s: address listed more than once", a.Address))
+		} else {
+			seen[key] = true
+		}
+		if err := validateSupplyString(a.Amount); err != nil {
+			errs.add("Allocations", fmt.Sprintf("allocation %s: %s", a.Address, err))
+			valid = false
+			continue
+		}
+		n, _ := new(big.Int).SetString(a.Amount, 10)
+		if n.Sign() == 0 {
+			errs.add("Allocations", fmt.Sprintf("allocation %s: amount must be greater than zero", a.Address))
+		}
+		sum.Add(sum, n)
+	}
+
+	initial, ok := new(big.Int).SetString(c.InitialSupply, 10)
+	if valid && ok && sum.Cmp(initial) != 0 {
+		errs.add("Allocations", fmt.Sprintf("allocations sum to %s tokens but the initial supply is %s", sum, c.InitialSupply))
+	}
+}
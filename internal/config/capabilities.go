@@ -0,0 +1,193 @@
+package config
+
+import "sort"
+
+// RuleKind is how a FeatureRule relates its feature to another.
+type RuleKind string
+
+const (
+	RuleRequires  RuleKind = "requires"  // the feature is invalid without Other
+	RuleConflicts RuleKind = "conflicts" // the feature is invalid together with Other
+	RuleImplies   RuleKind = "implies"   // enabling the feature turns Other on
+)
+
+// Capability is a feature the compatibility rules refer to, with the flag
+// that enables it.
+type Capability struct {
+	Name    string `json:"name"`
+	Flag    string `json:"flag"`
+	enabled func(*TokenConfig) bool
+	enable  func(*TokenConfig) // set only for targets of an implies rule
+}
+
+// FeatureRule is one entry of the compatibility matrix. Requires and
+// conflicts rules are reported by Validate on Field with Message; implies
+// rules are applied by Validate before the token is generated.
+type FeatureRule struct {
+	Feature   string    `json:"feature"`
+	Kind      RuleKind  `json:"kind"`
+	Other     string    `json:"other"`
+	OZVersion OZVersion `json:"ozVersion,omitempty"` // "" = every version
+	Field     string    `json:"field,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// Capabilities lists every feature named in FeatureRules, in flag order.
+var Capabilities = []Capability{
+	{Name: "InitialSupply", Flag: "--initial-supply", enabled: func(c *TokenConfig) bool { return c.InitialSupply != "" }},
+	{Name: "Capped", Flag: "--max-supply", enabled: func(c *TokenConfig) bool { return c.MaxSupply != "" }},
+	{Name: "FixedSupply", Flag: "--fixed-supply", enabled: func(c *TokenConfig) bool { return c.FixedSupply }},
+	{Name: "EmitCapReached", Flag: "--emit-cap-reached", enabled: func(c *TokenConfig) bool { return c.EmitCapReached }},
+	{Name: "Mintable", Flag: "--mintable", enabled: func(c *TokenConfig) bool { return c.Mintable }},
+	{Name: "Burnable", Flag: "--burnable", enabled: func(c *TokenConfig) bool { return c.Burnable }},
+	{Name: "AdminBurn", Flag: "--admin-burn", enabled: func(c *TokenConfig) bool { return c.AdminBurn }},
+	{Name: "Locks", Flag: "--with-locks", enabled: func(c *TokenConfig) bool { return c.Locks }},
+	{Name: "Pausable", Flag: "--pausable", enabled: func(c *TokenConfig) bool { return c.Pausable }},
+	{Name: "StartPaused", Flag: "--start-paused", enabled: func(c *TokenConfig) bool { return c.StartPaused }},
+	{Name: "Permit", Flag: "--permit",
+		enabled: func(c *TokenConfig) bool { return c.Permit },
+		enable:  func(c *TokenConfig) { c.Permit = true }},
+	{Name: "Snapshot", Flag: "--snapshot",
+		enabled: func(c *TokenConfig) bool { return c.Snapshot },
+		enable:  func(c *TokenConfig) { c.Snapshot = true }},
+	{Name: "Votes", Flag: "--votes", enabled: func(c *TokenConfig) bool { return c.Votes }},
+	{Name: "SnapshotOnDeploy", Flag: "--snapshot-on-deploy", enabled: func(c *TokenConfig) bool { return c.SnapshotOnDeploy }},
+	{Name: "Airdrop", Flag: "--with-airdrop", enabled: func(c *TokenConfig) bool { return c.Airdrop }},
+	{Name: "AirdropRestricted", Flag: "--airdrop-restricted", enabled: func(c *TokenConfig) bool { return c.AirdropRestricted }},
+	{Name: "MerkleClaim", Flag: "--with-merkle-claim", enabled: func(c *TokenConfig) bool { return c.MerkleClaim }},
+	{Name: "MerkleRoot", Flag: "--merkle-root", enabled: func(c *TokenConfig) bool { return c.MerkleRoot != "" }},
+	{Name: "Metadata", Flag: "--with-metadata", enabled: func(c *TokenConfig) bool { return c.Metadata }},
+	{Name: "MetadataURI", Flag: "--metadata-uri", enabled: func(c *TokenConfig) bool { return c.MetadataURI != "" }},
+	{Name: "BurnRebase", Flag: "--with-burn-rebase", enabled: func(c *TokenConfig) bool { return c.BurnRebase }},
+	{Name: "Bridge", Flag: "--bridge", enabled: func(c *TokenConfig) bool { return c.BridgeMinter != "" }},
+	{Name: "LinearMintSchedule", Flag: "--mint-schedule linear", enabled: func(c *TokenConfig) bool { return c.MintSchedule == MintScheduleLinear }},
+	{Name: "TimestampClock", Flag: "--clock-mode timestamp", enabled: func(c *TokenConfig) bool { return c.ClockMode == ClockTimestamp }},
+	{Name: "Governor", Flag: "--with-governor", enabled: func(c *TokenConfig) bool { return c.WithGovernor }},
+	{Name: "ReentrancyGuard", Flag: "--reentrancy-guard", enabled: func(c *TokenConfig) bool { return c.ReentrancyGuard }},
+	{Name: "GuardableFunction", Flag: "--mintable|--admin-burn|--bridge|--with-merkle-claim", enabled: func(c *TokenConfig) bool { return c.HasGuardableFunctions() }},
+	{Name: "AccessControl", Flag: "--access ownable|roles", enabled: func(c *TokenConfig) bool { return c.AccessControl != AccessNone }},
+	{Name: "Upgradeable", Flag: "--upgradeable uups|transparent", enabled: func(c *TokenConfig) bool { return c.IsUpgradeable() }},
+	{Name: "UUPS", Flag: "--upgradeable uups", enabled: func(c *TokenConfig) bool { return c.IsUUPS() }},
+	{Name: "ViemTests", Flag: "--test-style viem-ts", enabled: func(c *TokenConfig) bool { return c.TestStyle == TestStyleViemTS }},
+	{Name: "Typechain", Flag: "--with-typechain", enabled: func(c *TokenConfig) bool { return c.WithTypechain }},
+	{Name: "ExtraConstructorParams", Flag: "--ctor-param", enabled: func(c *TokenConfig) bool { return len(c.ExtraConstructorParams) > 0 }},
+	{Name: "ExposeParams", Flag: "--expose-params", enabled: func(c *TokenConfig) bool { return c.ExposeParams }},
+	{Name: "ExtractLibraries", Flag: "--extract-libraries", enabled: func(c *TokenConfig) bool { return c.ExtractLibraries }},
+	{Name: "OZv4", Flag: "--oz-version 4", enabled: func(c *TokenConfig) bool { return c.OZVersion == OZv4 }},
+}
+
+// FeatureRules is the compatibility matrix Validate enforces and
+// `capabilities` prints. Rules are checked in order; a message already
+// reported for a field is not repeated.
+var FeatureRules = []FeatureRule{
+	{"Mintable", RuleConflicts, "FixedSupply", "", "Mintable", "mintable conflicts with fixed supply — the cap already equals the initial supply"},
+	{"Upgradeable", RuleConflicts, "Capped", "", "MaxSupply", "capped supply is not supported for upgradeable tokens"},
+	{"UUPS", RuleRequires, "AccessControl", "", "AccessControl", "uups upgradeable tokens require access control to guard _authorizeUpgrade"},
+	{"AdminBurn", RuleConflicts, "Burnable", "", "AdminBurn", "admin burn cannot be combined with burnable — both define burnFrom(address,uint256)"},
+	{"AdminBurn", RuleRequires, "AccessControl", "", "AdminBurn", "admin burn requires access control — an unguarded burnFrom lets anyone destroy balances"},
+	{"Mintable", RuleRequires, "AccessControl", "", "Mintable", "mintable requires access control — an unguarded mint() lets anyone create tokens; use --access ownable or roles"},
+	{"Pausable", RuleRequires, "AccessControl", "", "Pausable", "pausable requires access control — an unguarded pause() lets anyone freeze transfers; use --access ownable or roles"},
+	{"Snapshot", RuleRequires, "AccessControl", "", "Snapshot", "snapshot requires access control — an unguarded snapshot() lets anyone spam snapshots; use --access ownable or roles"},
+	{"Locks", RuleRequires, "AccessControl", "", "Locks", "locks require access control — an unguarded lock() lets anyone freeze balances; use --access ownable or roles"},
+	{"AirdropRestricted", RuleRequires, "Airdrop", "", "AirdropRestricted", "airdrop restriction requires --with-airdrop"},
+	{"AirdropRestricted", RuleRequires, "AccessControl", "", "AirdropRestricted", "a restricted airdrop needs access control; use --access ownable or roles"},
+	{"MerkleClaim", RuleRequires, "MerkleRoot", "", "MerkleRoot", "--with-merkle-claim requires --merkle-root"},
+	{"MerkleClaim", RuleConflicts, "FixedSupply", "", "MerkleClaim", "merkle claim conflicts with fixed supply — claims mint new tokens"},
+	{"MerkleRoot", RuleRequires, "MerkleClaim", "", "MerkleRoot", "--merkle-root requires --with-merkle-claim"},
+	{"Metadata", RuleRequires, "AccessControl", "", "Metadata", "metadata requires access control — an unguarded setContractURI() lets anyone repoint the token's metadata; use --access ownable or roles"},
+	{"MetadataURI", RuleRequires, "Metadata", "", "MetadataURI", "--metadata-uri requires --with-metadata"},
+	{"BurnRebase", RuleRequires, "AccessControl", "", "BurnRebase", "a burn rebase requires access control — an unguarded applyBurnRebase() lets anyone burn every balance; use --access ownable or roles"},
+	{"BurnRebase", RuleConflicts, "Capped", "", "BurnRebase", "a burn rebase conflicts with --max-supply — ERC20Capped would check the cap against stored shares, not the rebased supply"},
+	{"BurnRebase", RuleConflicts, "Votes", "", "BurnRebase", "a burn rebase conflicts with votes — voting checkpoints would keep the pre-rebase balances"},
+	{"BurnRebase", RuleConflicts, "Snapshot", "", "BurnRebase", "a burn rebase conflicts with snapshot — snapshots would record shares, not balances"},
+	{"BurnRebase", RuleConflicts, "Upgradeable", "", "BurnRebase", "a burn rebase is not supported for upgradeable tokens — the rebase index is initialized inline, which proxies skip"},
+	{"BurnRebase", RuleConflicts, "OZv4", "", "BurnRebase", "a burn rebase requires --oz-version 5 — it rescales amounts in _update, which v4 does not have"},
+	{"Bridge", RuleConflicts, "Mintable", "", "BridgeMinter", "--bridge conflicts with mintable — both define mint(address,uint256)"},
+	{"Bridge", RuleConflicts, "FixedSupply", "", "BridgeMinter", "--bridge conflicts with fixed supply — the bridge must be able to mint"},
+	{"EmitCapReached", RuleRequires, "Mintable", "", "EmitCapReached", "emit cap reached requires --mintable and --max-supply — only mint() can fill the cap"},
+	{"EmitCapReached", RuleRequires, "Capped", "", "EmitCapReached", "emit cap reached requires --mintable and --max-supply — only mint() can fill the cap"},
+	{"StartPaused", RuleRequires, "Pausable", "", "StartPaused", "start paused requires the pausable feature"},
+	{"SnapshotOnDeploy", RuleRequires, "Snapshot", "", "SnapshotOnDeploy", "snapshot on deploy requires the snapshot feature"},
+	{"LinearMintSchedule", RuleRequires, "Mintable", "", "MintSchedule", "a linear mint schedule requires the mintable feature"},
+	{"TimestampClock", RuleRequires, "Votes", "", "ClockMode", "timestamp clock mode requires --votes — only ERC20Votes checkpoints read the clock"},
+	{"Governor", RuleRequires, "Votes", "", "WithGovernor", "a governor requires --votes — GovernorVotes reads voting power from the token"},
+	{"ReentrancyGuard", RuleRequires, "GuardableFunction", "", "ReentrancyGuard", "--reentrancy-guard has nothing to guard — enable --mintable, --admin-burn, --bridge or --with-merkle-claim"},
+	{"ExtraConstructorParams", RuleConflicts, "Upgradeable", "", "ExtraConstructorParams", "extra constructor params are not supported for upgradeable tokens — proxies run initialize(), not the constructor"},
+	{"ExposeParams", RuleConflicts, "Upgradeable", "", "ExposeParams", "--expose-params is not supported for upgradeable tokens — immutables are set by the implementation's constructor, not initialize()"},
+	{"ExtractLibraries", RuleConflicts, "Upgradeable", "", "ExtractLibraries", "extracted libraries are not supported for upgradeable tokens — linked external libraries are not upgrade-safe"},
+	{"Typechain", RuleConflicts, "ViemTests", "", "WithTypechain", "--with-typechain targets ethers — with viem-ts tests, hardhat-viem already generates contract types"},
+
+	// Votes builds on ERC20Permit in both OpenZeppelin versions; v4 also
+	// pairs it with Snapshot, which v5 removed.
+	{Feature: "Votes", Kind: RuleImplies, Other: "Snapshot", OZVersion: OZv4},
+	{Feature: "Votes", Kind: RuleImplies, Other: "Permit"},
+}
+
+// capability returns the Capabilities entry called name.
+func capability(name string) Capability {
+	for _, c := range Capabilities {
+		if c.Name == name {
+			return c
+		}
+	}
+	panic("config: unknown capability " + name)
+}
+
+// validateFeatureRules reports every violated requires or conflicts rule.
+func (c *TokenConfig) validateFeatureRules(errs *ValidationError) {
+	reported := map[FieldError]bool{}
+	for _, r := range FeatureRules {
+		if r.Kind == RuleImplies || !c.ruleApplies(r) {
+			continue
+		}
+		other := capability(r.Other).enabled(c)
+		if (r.Kind == RuleRequires && other) || (r.Kind == RuleConflicts && !other) {
+			continue
+		}
+		fe := FieldError{Field: r.Field, Message: r.Message}
+		if !reported[fe] {
+			reported[fe] = true
+			errs.add(r.Field, r.Message)
+		}
+	}
+}
+
+// applyImplications turns on the features implied by enabled ones. It runs
+// once OZVersion is settled.
+func (c *TokenConfig) applyImplications() {
+	for _, r := range FeatureRules {
+		if r.Kind == RuleImplies && c.ruleApplies(r) {
+			capability(r.Other).enable(c)
+		}
+	}
+}
+
+// ruleApplies reports whether r's feature is enabled under c's OZ version.
+func (c *TokenConfig) ruleApplies(r FeatureRule) bool {
+	if r.OZVersion != "" && r.OZVersion != c.OZVersion {
+		return false
+	}
+	return capability(r.Feature).enabled(c)
+}
+
+// Deprecation is one entry of the deprecation matrix.
+type Deprecation struct {
+	Construct string    `json:"construct"`
+	OZVersion OZVersion `json:"ozVersion"`
+	Message   string    `json:"message"`
+}
+
+// DeprecationMatrix returns the deprecation matrix sorted by construct.
+func DeprecationMatrix() []Deprecation {
+	out := make([]Deprecation, 0, len(deprecations))
+	for k, msg := range deprecations {
+		out = append(out, Deprecation{Construct: k.Feature, OZVersion: k.OZ, Message: msg})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Construct != out[j].Construct {
+			return out[i].Construct < out[j].Construct
+		}
+		return out[i].OZVersion < out[j].OZVersion
+	})
+	return out
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureRulesAreWellFormed(t *testing.T) {
+	known := map[string]Capability{}
+	for _, c := range Capabilities {
+		assert.NotContains(t, known, c.Name, "duplicate capability")
+		assert.NotNil(t, c.enabled, c.Name)
+		known[c.Name] = c
+	}
+	for _, r := range FeatureRules {
+		assert.Contains(t, known, r.Feature)
+		if !assert.Contains(t, known, r.Other) {
+			continue
+		}
+		if r.Kind == RuleImplies {
+			assert.NotNil(t, known[r.Other].enable, "%s implies %s, which cannot be enabled", r.Feature, r.Other)
+		} else {
+			assert.NotEmpty(t, r.Field, "%s %s %s", r.Feature, r.Kind, r.Other)
+			assert.NotEmpty(t, r.Message, "%s %s %s", r.Feature, r.Kind, r.Other)
+		}
+	}
+}
+
+func TestValidate_VotesImpliesSnapshotOnV4(t *testing.T) {
+	v4 := &TokenConfig{Name: "Gov", Symbol: "GOV", Decimals: 18, Votes: true, OZVersion: OZv4}
+	v5 := &TokenConfig{Name: "Gov", Symbol: "GOV", Decimals: 18, Votes: true, OZVersion: OZv5}
+	assert.NoError(t, v4.Validate())
+	assert.NoError(t, v5.Validate())
+	assert.True(t, v4.Snapshot)
+	assert.True(t, v4.Permit, "v4 ERC20Votes is ERC20Permit")
+	assert.True(t, v5.Permit)
+	assert.False(t, v5.Snapshot)
+}
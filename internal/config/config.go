@@ -1,12 +1,18 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // AccessControlType defines the access control model for the token.
@@ -18,82 +24,482 @@ const (
 	AccessNone    AccessControlType = "none"
 )
 
-// TokenConfig holds all parameters for ERC-20 token generation.
+// Values lists the valid AccessControlType values.
+func (AccessControlType) Values() []string {
+	return []string{string(AccessOwnable), string(AccessRoles), string(AccessNone)}
+}
+
+// ClockMode defines how ERC20Votes checkpoints are keyed (ERC-6372).
+type ClockMode string
+
+const (
+	ClockBlockNumber ClockMode = "blocknumber"
+	ClockTimestamp   ClockMode = "timestamp"
+)
+
+// Values lists the valid ClockMode values.
+func (ClockMode) Values() []string {
+	return []string{string(ClockBlockNumber), string(ClockTimestamp)}
+}
+
+// MintScheduleType defines how mint() issuance is limited over time.
+type MintScheduleType string
+
+const (
+	MintScheduleNone   MintScheduleType = "none"
+	MintScheduleLinear MintScheduleType = "linear"
+)
+
+// Values lists the valid MintScheduleType values.
+func (MintScheduleType) Values() []string {
+	return []string{string(MintScheduleNone), string(MintScheduleLinear)}
+}
+
+// OZVersion is the OpenZeppelin Contracts major version the output targets.
+type OZVersion string
+
+const (
+	OZv4 OZVersion = "4"
+	OZv5 OZVersion = "5"
+)
+
+// Values lists the valid OZVersion values.
+func (OZVersion) Values() []string {
+	return []string{string(OZv4), string(OZv5)}
+}
+
+// npm ranges pinned for @openzeppelin/contracts (and -upgradeable). v4 needs
+// 4.9 for the ERC-6372 clock used by --clock-mode timestamp.
+const (
+	OZv4Range = "^4.9.0"
+	OZv5Range = "^5.0.0"
+)
+
+// TestStyle selects the framework of the generated test skeleton.
+type TestStyle string
+
+const (
+	TestStyleEthersJS TestStyle = "ethers-js"
+	TestStyleViemTS   TestStyle = "viem-ts"
+)
+
+// Values lists the valid TestStyle values.
+func (TestStyle) Values() []string {
+	return []string{string(TestStyleEthersJS), string(TestStyleViemTS)}
+}
+
+// SymbolCase selects whether Validate uppercases Symbol or rejects
+// lowercase letters in it.
+type SymbolCase string
+
+const (
+	SymbolCaseAutoUpper SymbolCase = "auto-upper"
+	SymbolCaseStrict    SymbolCase = "strict"
+)
+
+// Values lists the valid SymbolCase values.
+func (SymbolCase) Values() []string {
+	return []string{string(SymbolCaseAutoUpper), string(SymbolCaseStrict)}
+}
+
+// GuardStyle selects how generated checks are written.
+type GuardStyle string
+
+const (
+	GuardRequire  GuardStyle = "require"   // require(condition, reason or error)
+	GuardIfRevert GuardStyle = "if-revert" // if (!condition) revert ...
+)
+
+// Values lists the valid GuardStyle values.
+func (GuardStyle) Values() []string {
+	return []string{string(GuardRequire), string(GuardIfRevert)}
+}
+
+// EVMVersion is the solc evmVersion setting of the generated compiler
+// config.
+type EVMVersion string
+
+const (
+	EVMLondon   EVMVersion = "london"
+	EVMParis    EVMVersion = "paris" // last target without PUSH0, for chains that lack it
+	EVMShanghai EVMVersion = "shanghai"
+	EVMCancun   EVMVersion = "cancun"
+	EVMPrague   EVMVersion = "prague"
+)
+
+// Values lists the valid EVMVersion values.
+func (EVMVersion) Values() []string {
+	return []string{string(EVMLondon), string(EVMParis), string(EVMShanghai), string(EVMCancun), string(EVMPrague)}
+}
+
+// ContractStyle selects the comment density of the generated contract.
+type ContractStyle string
+
+const (
+	StyleStandard ContractStyle = "standard" // NatSpec on the contract and public functions
+	StyleMinimal  ContractStyle = "minimal"  // no header, comments, or blank lines
+	StyleVerbose  ContractStyle = "verbose"  // standard plus --explain notes and a privileges summary
+)
+
+// Values lists the valid ContractStyle values.
+func (ContractStyle) Values() []string {
+	return []string{string(StyleStandard), string(StyleMinimal), string(StyleVerbose)}
+}
+
+// DeployStyle selects the flavor of the generated deploy script.
+type DeployStyle string
+
+const (
+	DeployStyleEthers        DeployStyle = "ethers"         // scripts/ run with `hardhat run`
+	DeployStyleHardhatDeploy DeployStyle = "hardhat-deploy" // deploy/ module for the hardhat-deploy plugin
+)
+
+// Values lists the valid DeployStyle values.
+func (DeployStyle) Values() []string {
+	return []string{string(DeployStyleEthers), string(DeployStyleHardhatDeploy)}
+}
+
+// LineEnding selects the line terminator of generated files.
+type LineEnding string
+
+const (
+	LineEndingLF   LineEnding = "lf"
+	LineEndingCRLF LineEnding = "crlf"
+)
+
+// Values lists the valid LineEnding values.
+func (LineEnding) Values() []string {
+	return []string{string(LineEndingLF), string(LineEndingCRLF)}
+}
+
+// UpgradeableType defines the proxy pattern used for upgradeable tokens.
+type UpgradeableType string
+
+const (
+	UpgradeNone        UpgradeableType = "none"
+	UpgradeUUPS        UpgradeableType = "uups"
+	UpgradeTransparent UpgradeableType = "transparent"
+)
+
+// Values lists the valid UpgradeableType values.
+func (UpgradeableType) Values() []string {
+	return []string{string(UpgradeNone), string(UpgradeUUPS), string(UpgradeTransparent)}
+}
+
+const (
+	ozContractsPrefix   = "@openzeppelin/contracts/"
+	ozUpgradeablePrefix = "@openzeppelin/contracts-upgradeable/"
+)
+
+// TokenConfig holds all parameters for ERC-20 token generation. The flag
+// tag names the generate flag (and config-file key) a field is read from.
 type TokenConfig struct {
 	// Core ERC-20 fields
-	Name            string
-	Symbol          string
-	Decimals        uint8
-	InitialSupply   string // human-readable, e.g. "1000000"
-	MaxSupply       string // empty = unlimited
+	Name           string     `flag:"name"`
+	DisplayName    string     `flag:"display-name"` // on-chain name(), may be unicode ("" = Name)
+	Symbol         string     `flag:"symbol"`
+	SymbolCase     SymbolCase `flag:"symbol-case"`
+	Decimals       uint8      `flag:"decimals"`
+	InitialSupply  string     `flag:"initial-supply"`   // human-readable, e.g. "1000000"
+	MaxSupply      string     `flag:"max-supply"`       // empty = unlimited
+	FixedSupply    bool       `flag:"fixed-supply"`     // cap = initial supply, no minting
+	EmitCapReached bool       `flag:"emit-cap-reached"` // mint() emits CapReached when it fills the cap
+	InitialHolder  string     `flag:"initial-holder"`   // receives the initial supply ("" = deployer/admin)
+
+	// Genesis split of InitialSupply across several addresses; the amounts
+	// must sum to InitialSupply. Empty = one mint to the initial holder.
+	Allocations []Allocation `flag:"allocation"`
 
 	// Feature flags
-	Mintable  bool
-	Burnable  bool
-	Pausable  bool
-	Permit    bool // EIP-2612
-	Snapshot  bool
-	Votes     bool
+	Mintable    bool `flag:"mintable"`
+	Burnable    bool `flag:"burnable"`
+	AdminBurn   bool `flag:"admin-burn"` // access-controlled burnFrom without allowance
+	Locks       bool `flag:"with-locks"` // admin lock() of part of a balance until a release time
+	Pausable    bool `flag:"pausable"`
+	StartPaused bool `flag:"start-paused"` // pause transfers at deployment (requires Pausable)
+	Permit      bool `flag:"permit"`       // EIP-2612
+	Snapshot    bool `flag:"snapshot"`
+	Votes       bool `flag:"votes"`
+
+	// Revert transfers and mints to the token contract's own address,
+	// where tokens would be stuck for good
+	RejectSelfTransfer bool `flag:"reject-self-transfer"`
+
+	// Inherit ReentrancyGuard and mark mint, burnFrom, the bridge's
+	// mint/burn and claim nonReentrant
+	ReentrancyGuard bool `flag:"reentrancy-guard"`
+
+	// Take snapshot 1 in the constructor, recording the genesis balances
+	// (e.g. for retroactive airdrop eligibility); requires Snapshot
+	SnapshotOnDeploy bool `flag:"snapshot-on-deploy"`
+
+	// batchTransfer(address[],uint256[]) from the caller's balance;
+	// AirdropRestricted limits it to the owner / DEFAULT_ADMIN_ROLE
+	Airdrop           bool `flag:"with-airdrop"`
+	AirdropRestricted bool `flag:"airdrop-restricted"`
+
+	// claim(amount, proof) minting to accounts listed in a merkle tree
+	// of (account, amount) leaves rooted at MerkleRoot (32-byte hex)
+	MerkleClaim bool   `flag:"with-merkle-claim"`
+	MerkleRoot  string `flag:"merkle-root"`
+
+	// ERC-7572 contractURI() with an admin setter, starting at MetadataURI
+	// (http(s):// or ipfs://, "" = empty until the admin sets one)
+	Metadata    bool   `flag:"with-metadata"`
+	MetadataURI string `flag:"metadata-uri"`
+
+	// Admin applyBurnRebase(bps) that burns the same share of every
+	// balance at once (negative rebase), at most BurnRebaseMaxBps per call
+	BurnRebase       bool `flag:"with-burn-rebase"`
+	BurnRebaseMaxBps int  `flag:"burn-rebase-max-bps"`
+
+	// Bridge address allowed to mint(address,uint256) and
+	// burn(address,uint256) for burn-and-mint bridges ("" = none)
+	BridgeMinter string `flag:"bridge"`
+
+	// Emission schedule for mint() (requires Mintable)
+	MintSchedule          MintScheduleType `flag:"mint-schedule"`
+	EmissionRatePerSecond string           `flag:"emission-rate"`  // whole tokens per second
+	EmissionStart         int64            `flag:"emission-start"` // unix timestamp the schedule starts accruing
+
+	// Votes checkpoint clock (blocknumber = OpenZeppelin default)
+	ClockMode ClockMode `flag:"clock-mode"`
+
+	// Companion OpenZeppelin Governor for a Votes token. Delay and period
+	// are in the token's clock units (blocks, or seconds with timestamp).
+	WithGovernor          bool  `flag:"with-governor"`
+	GovernorVotingDelay   int64 `flag:"voting-delay"`
+	GovernorVotingPeriod  int64 `flag:"voting-period"`
+	GovernorQuorumPercent uint8 `flag:"quorum-percent"`
 
 	// Access control
-	AccessControl AccessControlType
+	AccessControl AccessControlType `flag:"access"`
+
+	// Roles mode: address the deploy scripts pass as defaultAdmin, which is
+	// granted DEFAULT_ADMIN_ROLE and every feature role ("" = deployer)
+	AdminAddress string `flag:"admin-address"`
+
+	// Proxy pattern (none = plain constructor-based contract)
+	Upgradeable UpgradeableType `flag:"upgradeable"`
 
 	// Metadata
-	License         string
-	SolidityVersion string
+	License         LicenseType `flag:"license"`
+	Title           string      `flag:"title"`  // NatSpec @title (default: Name)
+	Author          string      `flag:"author"` // NatSpec @author
+	Notice          string      `flag:"notice"` // NatSpec @notice
+	OZVersion       OZVersion   `flag:"oz-version"`
+	SolidityVersion string      `flag:"solidity-version"`
+
+	// solc evmVersion written to the generated compiler config ("" = the
+	// compiler's default, and no compiler config)
+	EVMVersion EVMVersion `flag:"evm-version"`
 
 	// Output options
-	WithDeploy bool
-	WithTest   bool
+	WithDeploy    bool        `flag:"with-deploy"`
+	DeployStyle   DeployStyle `flag:"deploy-style"`
+	WithTest      bool        `flag:"with-test"`
+	TestStyle     TestStyle   `flag:"test-style"`
+	WithABI       bool        `flag:"with-abi"`
+	WithReadme    bool        `flag:"with-readme"`
+	WithCI        bool        `flag:"with-ci"`
+	WithEnv       bool        `flag:"with-env"`
+	WithTypechain bool        `flag:"with-typechain"`
+
+	// Extra "<type> <name>" constructor params stored in immutables
+	ExtraConstructorParams []string `flag:"ctor-param"`
+
+	// Record the initial supply and cap in public immutables too
+	ExposeParams bool `flag:"expose-params"`
+
+	// Advanced: extra Solidity imports and parent contracts appended to the
+	// generated ones. Overrides the parents require are not generated.
+	ExtraImports []string `flag:"extra-import"`
+	ExtraParents []string `flag:"extra-parent"`
+
+	// Deploy script target ("" = network-agnostic)
+	Network string `flag:"network"`
+
+	// Per-network owner/treasury for a multi-network deploy script, keyed by
+	// Hardhat network name (the config file's "networks" block)
+	NetworkConfigs map[string]NetworkParams
+
+	// Annotate generated code with educational comments
+	Explain bool `flag:"explain"`
+
+	// Strip comments and blank lines from the generated contract
+	Minify bool `flag:"minify"`
+
+	// Contract template variant: standard, minimal, or verbose
+	Style ContractStyle `flag:"style"`
+
+	// Render the contract's imports in commented blocks by category
+	GroupImports bool `flag:"group-imports"`
+
+	// Indentation of generated code: "tabs" or a space count ("" = as
+	// templated, 4 spaces in Solidity and 2 in JS/TS/JSON)
+	Indent string `flag:"indent"`
+
+	// Line endings of generated files
+	EOL LineEnding `flag:"eol"`
+
+	// Wrap provably safe arithmetic in unchecked blocks
+	Optimize bool `flag:"optimize"`
+
+	// Move helper math into linked Solidity libraries
+	ExtractLibraries bool `flag:"extract-libraries"`
+
+	// How generated checks revert ("" = require with reason strings,
+	// if-revert with custom errors)
+	GuardStyle GuardStyle `flag:"guard-style"`
+
+	// Skip the events declared for admin actions (--with-events=false)
+	OmitEvents bool
+
+	// Revert with require() reason strings instead of custom errors
+	// (--custom-errors=false; the CLI default for OZ v4)
+	RequireStrings bool
+
+	// Reject deprecated OpenZeppelin patterns instead of warning
+	Strict bool `flag:"strict"`
+
+	// Warn when Symbol matches a well-known token's ticker. Only affects
+	// warnings, so it is excluded from the config hash.
+	CheckTicker bool `json:"-" flag:"check-ticker"`
+
+	// Record version, features, and config hash in the contract header.
+	// Excluded from the hash itself so toggling it doesn't change the digest.
+	Provenance bool `json:"-" flag:"provenance"`
 }
 
+// MaxDecimals is the largest decimals value for which one whole token
+// (10**decimals base units) still fits in a uint256. Values above
+// StandardDecimals are allowed but warned about.
+const (
+	MaxDecimals      = 77
+	StandardDecimals = 18
+)
+
 var (
 	validSymbolRe   = regexp.MustCompile(`^[A-Z0-9]{1,11}$`)
 	validNameRe     = regexp.MustCompile(`^[A-Za-z0-9 _\-]{1,64}$`)
 	validDecimalNum = regexp.MustCompile(`^\d+$`)
+	validIndentRe   = regexp.MustCompile(`^(tabs|[1-8])$`)
+	merkleRootRe    = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	metadataURIRe   = regexp.MustCompile(`^(https?|ipfs)://[!#-\[\]-~]+$`)
 )
 
 // Validate performs comprehensive input validation with clear error messages.
 func (c *TokenConfig) Validate() error {
-	var errs []string
+	var errs ValidationError
 
 	// Name
 	if strings.TrimSpace(c.Name) == "" {
-		errs = append(errs, "token name is required")
+		errs.add("Name", "token name is required")
 	} else if !validNameRe.MatchString(c.Name) {
-		errs = append(errs, "token name must be 1-64 alphanumeric characters (spaces, hyphens, underscores allowed)")
+		msg := "token name must be 1-64 alphanumeric characters (spaces, hyphens, underscores allowed)"
+		if !isASCII(c.Name) {
+			msg += " — keep it ASCII and set --display-name for a unicode name()"
+		}
+		errs.add("Name", msg)
+	}
+
+	// Display name
+	if c.DisplayName != "" {
+		if err := validateDisplayName(c.DisplayName); err != nil {
+			errs.add("DisplayName", fmt.Sprintf("display name: %s", err))
+		}
+	}
+
+	// NatSpec: a newline or "*/" would break out of the doc comment
+	for _, f := range []struct{ field, value string }{
+		{"Title", c.Title}, {"Author", c.Author}, {"Notice", c.Notice},
+	} {
+		if strings.ContainsAny(f.value, "\r\n") || strings.Contains(f.value, "*/") {
+			errs.add(f.field, fmt.Sprintf("%s must be a single line and must not contain \"*/\"", strings.ToLower(f.field)))
+		}
 	}
 
 	// Symbol
+	switch c.SymbolCase {
+	case SymbolCaseStrict:
+		// valid
+	case "":
+		c.SymbolCase = SymbolCaseAutoUpper
+		c.Symbol = strings.ToUpper(c.Symbol)
+	case SymbolCaseAutoUpper:
+		c.Symbol = strings.ToUpper(c.Symbol)
+	default:
+		errs.add("SymbolCase", fmt.Sprintf("invalid symbol case %q — must be: auto-upper or strict", c.SymbolCase))
+	}
 	if strings.TrimSpace(c.Symbol) == "" {
-		errs = append(errs, "token symbol is required")
+		errs.add("Symbol", "token symbol is required")
 	} else if !validSymbolRe.MatchString(c.Symbol) {
-		errs = append(errs, "token symbol must be 1-11 uppercase letters/digits (e.g. MTK, USDC)")
+		msg := "token symbol must be 1-11 uppercase letters/digits (e.g. MTK, USDC)"
+		if validSymbolRe.MatchString(strings.ToUpper(c.Symbol)) {
+			msg += "; --symbol-case auto-upper uppercases it for you"
+		}
+		errs.add("Symbol", msg)
 	}
 
 	// Decimals
-	if c.Decimals > 18 {
-		errs = append(errs, "decimals must be between 0 and 18")
+	if c.Decimals > MaxDecimals {
+		errs.add("Decimals", fmt.Sprintf("decimals must be between 0 and %d — 10**%d overflows uint256", MaxDecimals, c.Decimals))
 	}
 
 	// Initial supply
 	if c.InitialSupply != "" {
 		if err := validateSupplyString(c.InitialSupply); err != nil {
-			errs = append(errs, fmt.Sprintf("initial supply: %s", err))
+			errs.add("InitialSupply", fmt.Sprintf("initial supply: %s", err))
+		} else if !c.fitsUint256(c.InitialSupply) {
+			errs.add("InitialSupply", fmt.Sprintf("initial supply: %s tokens at %d decimals overflows uint256", c.InitialSupply, c.Decimals))
+		}
+	}
+
+	// Initial holder
+	if c.InitialHolder != "" {
+		if err := validateAddress(c.InitialHolder); err != nil {
+			errs.add("InitialHolder", fmt.Sprintf("initial holder: %s", err))
+		} else if c.InitialSupply == "" {
+			errs.add("InitialHolder", "an initial holder requires an initial supply to receive")
+		}
+	}
+
+	// Genesis allocations
+	if len(c.Allocations) > 0 {
+		c.validateAllocations(&errs)
+	}
+
+	// Fixed supply: the cap is the genesis mint
+	if c.FixedSupply {
+		switch {
+		case c.InitialSupply == "":
+			errs.add("FixedSupply", "fixed supply requires an initial supply — it becomes the cap")
+		case c.MaxSupply == "":
+			c.MaxSupply = c.InitialSupply
+		default:
+			initial, _ := new(big.Int).SetString(c.InitialSupply, 10)
+			max, _ := new(big.Int).SetString(c.MaxSupply, 10)
+			if initial != nil && max != nil && initial.Cmp(max) != 0 {
+				errs.add("MaxSupply", "max supply must equal initial supply for a fixed-supply token")
+			}
 		}
 	}
 
 	// Max supply
 	if c.MaxSupply != "" {
 		if err := validateSupplyString(c.MaxSupply); err != nil {
-			errs = append(errs, fmt.Sprintf("max supply: %s", err))
+			errs.add("MaxSupply", fmt.Sprintf("max supply: %s", err))
+		} else if !c.fitsUint256(c.MaxSupply) {
+			errs.add("MaxSupply", fmt.Sprintf("max supply: %s tokens at %d decimals overflows uint256", c.MaxSupply, c.Decimals))
+		} else if c.toUnits(c.MaxSupply).Sign() == 0 {
+			errs.add("MaxSupply", "max supply must be greater than zero — ERC20Capped reverts on deployment with a zero cap")
 		}
 		// Ensure max >= initial
 		if c.InitialSupply != "" {
 			initial, _ := new(big.Int).SetString(c.InitialSupply, 10)
 			max, _ := new(big.Int).SetString(c.MaxSupply, 10)
 			if initial != nil && max != nil && initial.Cmp(max) > 0 {
-				errs = append(errs, "initial supply cannot exceed max supply")
+				errs.add("InitialSupply", "initial supply cannot exceed max supply")
 			}
 		}
 	}
@@ -105,18 +511,207 @@ func (c *TokenConfig) Validate() error {
 	case "":
 		c.AccessControl = AccessOwnable
 	default:
-		errs = append(errs, fmt.Sprintf("invalid access control type %q — must be: ownable, roles, or none", c.AccessControl))
+		errs.add("AccessControl", fmt.Sprintf("invalid access control type %q — must be: ownable, roles, or none", c.AccessControl))
+	}
+
+	// Explicit roles admin
+	if c.AdminAddress != "" {
+		if err := validateAddress(c.AdminAddress); err != nil {
+			errs.add("AdminAddress", fmt.Sprintf("admin address: %s", err))
+		} else if !c.NeedsRoles() {
+			errs.add("AdminAddress", "--admin-address requires --access roles")
+		} else if len(c.NetworkConfigs) > 0 {
+			errs.add("AdminAddress", "--admin-address conflicts with per-network owners — set networks.<name>.owner instead")
+		}
 	}
 
-	// Votes requires Snapshot (OpenZeppelin coupling)
-	if c.Votes && !c.Snapshot {
-		// auto-enable snapshot when votes is on
-		c.Snapshot = true
+	// Upgradeability
+	switch c.Upgradeable {
+	case UpgradeNone, UpgradeUUPS, UpgradeTransparent:
+		// valid
+	case "":
+		c.Upgradeable = UpgradeNone
+	default:
+		errs.add("Upgradeable", fmt.Sprintf("invalid upgradeable type %q — must be: none, uups, or transparent", c.Upgradeable))
+	}
+
+	// Requires/conflicts rules between features
+	c.validateFeatureRules(&errs)
+
+	// Bridge minter address
+	if c.BridgeMinter != "" {
+		if err := validateAddress(c.BridgeMinter); err != nil {
+			errs.add("BridgeMinter", fmt.Sprintf("bridge address: %s", err))
+		}
+	}
+
+	// Merkle claim root
+	if c.MerkleRoot != "" {
+		if !merkleRootRe.MatchString(c.MerkleRoot) {
+			errs.add("MerkleRoot", fmt.Sprintf("invalid merkle root %q — must be 0x followed by 64 hex characters", c.MerkleRoot))
+		} else if strings.Trim(c.MerkleRoot[2:], "0") == "" {
+			errs.add("MerkleRoot", "merkle root must not be zero — no proof verifies against it")
+		}
+	}
+
+	// Contract metadata URI, embedded as a Solidity string literal
+	if c.MetadataURI != "" && !metadataURIRe.MatchString(c.MetadataURI) {
+		errs.add("MetadataURI", fmt.Sprintf("invalid metadata URI %q — must be an http://, https://, or ipfs:// URI without spaces, quotes, or backslashes", c.MetadataURI))
+	}
+
+	if c.BurnRebase {
+		c.validateBurnRebase(&errs)
+	}
+
+	// Mint schedule
+	switch c.MintSchedule {
+	case MintScheduleNone:
+		// valid
+	case "":
+		c.MintSchedule = MintScheduleNone
+	case MintScheduleLinear:
+		if err := validateSupplyString(c.EmissionRatePerSecond); err != nil {
+			errs.add("EmissionRatePerSecond", fmt.Sprintf("emission rate: %s", err))
+		} else if rate, _ := new(big.Int).SetString(strings.TrimSpace(c.EmissionRatePerSecond), 10); rate.Sign() == 0 {
+			errs.add("EmissionRatePerSecond", "emission rate must be greater than zero")
+		} else if !c.fitsUint256(c.EmissionRatePerSecond) {
+			errs.add("EmissionRatePerSecond", fmt.Sprintf("emission rate: %s tokens at %d decimals overflows uint256", c.EmissionRatePerSecond, c.Decimals))
+		}
+		if c.EmissionStart <= 0 {
+			errs.add("EmissionStart", "emission start must be a positive unix timestamp")
+		}
+	default:
+		errs.add("MintSchedule", fmt.Sprintf("invalid mint schedule %q — must be: none or linear", c.MintSchedule))
+	}
+
+	// Clock mode. blocknumber is OpenZeppelin's default and needs no code;
+	// timestamp overrides clock() and CLOCK_MODE(), which only Votes has.
+	switch c.ClockMode {
+	case ClockBlockNumber, ClockTimestamp:
+		// valid
+	case "":
+		c.ClockMode = ClockBlockNumber
+	default:
+		errs.add("ClockMode", fmt.Sprintf("invalid clock mode %q — must be: blocknumber or timestamp", c.ClockMode))
+	}
+
+	// Extra constructor params
+	seen := map[string]bool{}
+	for _, s := range c.ExtraConstructorParams {
+		p, err := parseCtorParam(s)
+		if err != nil {
+			errs.add("ExtraConstructorParams", err.Error())
+			continue
+		}
+		if seen[p.Name] {
+			errs.add("ExtraConstructorParams", fmt.Sprintf("duplicate constructor param %q", p.Name))
+		} else if c.ExposeParams && exposedParamNames[p.Name] {
+			errs.add("ExtraConstructorParams", fmt.Sprintf("constructor param %q collides with the immutable --expose-params declares", p.Name))
+		}
+		seen[p.Name] = true
+	}
+
+	// Contract style
+	switch c.Style {
+	case StyleStandard:
+		// valid
+	case "":
+		c.Style = StyleStandard
+	case StyleMinimal:
+		if c.Explain {
+			errs.add("Style", "--explain conflicts with --style minimal, which strips comments")
+		}
+	case StyleVerbose:
+		if c.Minify {
+			errs.add("Style", "--minify conflicts with --style verbose")
+		}
+	default:
+		errs.add("Style", fmt.Sprintf("invalid style %q — must be: standard, minimal, or verbose", c.Style))
+	}
+
+	// Test style
+	switch c.TestStyle {
+	case TestStyleEthersJS:
+		// valid
+	case "":
+		c.TestStyle = TestStyleEthersJS
+	case TestStyleViemTS:
+		if c.IsUpgradeable() {
+			errs.add("TestStyle", "viem-ts tests do not support upgradeable tokens — the OpenZeppelin upgrades plugin requires ethers")
+		}
+	default:
+		errs.add("TestStyle", fmt.Sprintf("invalid test style %q — must be: ethers-js or viem-ts", c.TestStyle))
+	}
+
+	// Deploy style
+	switch c.DeployStyle {
+	case DeployStyleEthers, DeployStyleHardhatDeploy:
+		// valid
+	case "":
+		c.DeployStyle = DeployStyleEthers
+	default:
+		errs.add("DeployStyle", fmt.Sprintf("invalid deploy style %q — must be: ethers or hardhat-deploy", c.DeployStyle))
+	}
+
+	// Whitespace style
+	if c.Indent != "" && !validIndentRe.MatchString(c.Indent) {
+		errs.add("Indent", fmt.Sprintf("invalid indent %q — must be tabs or a space count from 1 to 8", c.Indent))
+	}
+	switch c.EOL {
+	case LineEndingLF, LineEndingCRLF:
+		// valid
+	case "":
+		c.EOL = LineEndingLF
+	default:
+		errs.add("EOL", fmt.Sprintf("invalid line ending %q — must be: lf or crlf", c.EOL))
+	}
+
+	// Network
+	if c.Network != "" && c.NetworkInfo() == nil {
+		names := make([]string, len(KnownNetworks))
+		for i, n := range KnownNetworks {
+			names[i] = n.Name
+		}
+		errs.add("Network", fmt.Sprintf("unknown network %q — must be one of: %s", c.Network, strings.Join(names, ", ")))
+	}
+	if len(c.NetworkConfigs) > 0 {
+		c.validateNetworkConfigs(&errs)
+	}
+
+	// OpenZeppelin version
+	switch c.OZVersion {
+	case OZv4, OZv5:
+		// valid
+	case "":
+		c.OZVersion = OZv5
+	default:
+		errs.add("OZVersion", fmt.Sprintf("invalid OpenZeppelin version %q — must be: 4 or 5", c.OZVersion))
+	}
+
+	// Features implied by others, which can depend on the OZ version
+	c.applyImplications()
+
+	// Extra imports and parents, checked against the settled feature set
+	c.validateExtras(&errs)
+
+	if c.WithGovernor {
+		c.validateGovernor(&errs)
+	}
+
+	// Deprecated OpenZeppelin patterns are errors only in strict mode
+	if c.Strict {
+		for _, msg := range c.Deprecations() {
+			errs.add("Strict", msg)
+		}
 	}
 
 	// License
 	if c.License == "" {
-		c.License = "MIT"
+		c.License = LicenseMIT
+	} else if l, err := normalizeLicense(c.License); err != nil {
+		errs.add("License", err.Error())
+	} else {
+		c.License = l
 	}
 
 	// Solidity version
@@ -124,12 +719,63 @@ func (c *TokenConfig) Validate() error {
 		c.SolidityVersion = "^0.8.24"
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n  - "))
+	// Guard style: require(condition, CustomError()) is newer than the
+	// custom errors themselves
+	switch c.GuardStyle {
+	case "", GuardIfRevert:
+		// valid
+	case GuardRequire:
+		if !c.RequireStrings && !versionAtLeast(c.CompilerVersion(), minRequireErrorSolc) {
+			errs.add("GuardStyle", fmt.Sprintf("require with custom errors needs solc %s or later — set --solidity-version ^%s, or use --custom-errors=false", minRequireErrorSolc, minRequireErrorSolc))
+		}
+	default:
+		errs.add("GuardStyle", fmt.Sprintf("invalid guard style %q — must be: require or if-revert", c.GuardStyle))
+	}
+
+	// EVM target
+	switch c.EVMVersion {
+	case "":
+		// compiler default
+	case EVMLondon, EVMParis, EVMShanghai, EVMCancun, EVMPrague:
+		// valid
+	default:
+		errs.add("EVMVersion", fmt.Sprintf("invalid EVM version %q — must be: london, paris, shanghai, cancun, or prague", c.EVMVersion))
+	}
+
+	if len(errs.Fields) > 0 {
+		return &errs
+	}
+	return nil
+}
+
+// MaxDisplayNameLength caps DisplayName in characters; wallets and
+// explorers truncate long token names well before this.
+const MaxDisplayNameLength = 64
+
+// validateDisplayName checks a name() string: unicode is allowed, but
+// quotes, backslashes and control characters would need escaping in the
+// Solidity and JavaScript literals it is written into.
+func validateDisplayName(s string) error {
+	switch n := utf8.RuneCountInString(s); {
+	case !utf8.ValidString(s):
+		return errors.New("must be valid UTF-8")
+	case strings.TrimSpace(s) != s:
+		return errors.New("must not start or end with whitespace")
+	case n > MaxDisplayNameLength:
+		return fmt.Errorf("%d characters is too long — at most %d", n, MaxDisplayNameLength)
+	case strings.ContainsAny(s, `"\`):
+		return errors.New(`must not contain " or \`)
+	case strings.IndexFunc(s, unicode.IsControl) >= 0:
+		return errors.New("must not contain control characters")
 	}
 	return nil
 }
 
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r > unicode.MaxASCII }) < 0
+}
+
 func validateSupplyString(s string) error {
 	s = strings.TrimSpace(s)
 	if !validDecimalNum.MatchString(s) {
@@ -150,6 +796,19 @@ func (c *TokenConfig) ContractFileName() string {
 	return c.SafeName() + ".sol"
 }
 
+// TestFileName returns the test skeleton filename for the chosen style.
+func (c *TokenConfig) TestFileName() string {
+	if c.TestStyle == TestStyleViemTS {
+		return c.SafeName() + ".test.ts"
+	}
+	return c.SafeName() + ".test.js"
+}
+
+// InvariantTestFileName returns the Foundry invariant test filename.
+func (c *TokenConfig) InvariantTestFileName() string {
+	return c.SafeName() + "Invariants.t.sol"
+}
+
 // SafeName returns a filesystem-safe version of the token name for use in filenames.
 func (c *TokenConfig) SafeName() string {
 	safe := strings.Map(func(r rune) rune {
@@ -161,6 +820,263 @@ func (c *TokenConfig) SafeName() string {
 	return safe
 }
 
+// toUnits scales a whole-token amount to the token's smallest unit
+// (amount * 10^Decimals). It returns nil if amount is not an integer.
+func (c *TokenConfig) toUnits(amount string) *big.Int {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+	if !ok {
+		return nil
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil)
+	return n.Mul(n, scale)
+}
+
+// fitsUint256 reports whether amount whole tokens fit in a uint256 once
+// scaled by 10^Decimals.
+func (c *TokenConfig) fitsUint256(amount string) bool {
+	units := c.toUnits(amount)
+	return units != nil && units.BitLen() <= 256
+}
+
+// MaxSupplyUnits returns the max supply in the token's smallest unit
+// (MaxSupply * 10^Decimals), or "" if no cap is configured.
+func (c *TokenConfig) MaxSupplyUnits() string {
+	if units := c.toUnits(c.MaxSupply); units != nil {
+		return units.String()
+	}
+	return ""
+}
+
+// Warnings returns non-fatal concerns about a config that passes Validate.
+// The CLI prints them before generating.
+func (c *TokenConfig) Warnings() []string {
+	var msgs []string
+	if c.Decimals > StandardDecimals {
+		msgs = append(msgs, fmt.Sprintf("%d decimals exceeds the standard %d — many wallets, explorers, and exchanges display or round such balances incorrectly", c.Decimals, StandardDecimals))
+	}
+	if strings.EqualFold(strings.TrimSpace(c.TokenName()), c.Symbol) {
+		msgs = append(msgs, fmt.Sprintf("name %q matches symbol %s — wallets show both side by side, so a descriptive name (e.g. %q) reads better", c.TokenName(), c.Symbol, c.Symbol+" Token"))
+	}
+	if c.CheckTicker {
+		if msg := c.tickerWarning(); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	if c.InitialSupply == "" && !c.Mintable && !c.HasBridge() {
+		msgs = append(msgs, "no initial supply and no way to mint — this token can never have a nonzero supply")
+	}
+	if msg := c.extrasWarning(); msg != "" {
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Explains returns true if the contract carries the --explain notes, which
+// the verbose style always includes.
+func (c *TokenConfig) Explains() bool {
+	return c.Explain || c.Style == StyleVerbose
+}
+
+// Minifies returns true if comments and blank lines are stripped from the
+// contract, as --minify and the minimal style do.
+func (c *TokenConfig) Minifies() bool {
+	return c.Minify || c.Style == StyleMinimal
+}
+
+// TokenName returns the string the contract's name() returns: DisplayName
+// when set, otherwise Name. The contract identifier always derives from Name.
+func (c *TokenConfig) TokenName() string {
+	if c.DisplayName != "" {
+		return c.DisplayName
+	}
+	return c.Name
+}
+
+// NatSpecTitle returns the contract's NatSpec @title, defaulting to Name.
+func (c *TokenConfig) NatSpecTitle() string {
+	if t := strings.TrimSpace(c.Title); t != "" {
+		return t
+	}
+	return c.Name
+}
+
+// OZVersionString returns the npm semver range of OpenZeppelin Contracts
+// the generated code is written against (v5 when OZVersion is unset).
+func (c *TokenConfig) OZVersionString() string {
+	if c.OZVersion == OZv4 {
+		return OZv4Range
+	}
+	return OZv5Range
+}
+
+// EnabledFeatures lists the names of the feature flags that are on.
+func (c *TokenConfig) EnabledFeatures() []string {
+	var features []string
+	for _, f := range []struct {
+		on   bool
+		name string
+	}{
+		{c.Mintable, "Mintable"},
+		{c.EmitCapReached, "CapReachedEvent"},
+		{c.HasBridge(), "Bridge"},
+		{c.Airdrop, "Airdrop"},
+		{c.MerkleClaim, "MerkleClaim"},
+		{c.Metadata, "Metadata"},
+		{c.BurnRebase, "BurnRebase"},
+		{c.Burnable, "Burnable"},
+		{c.AdminBurn, "AdminBurn"},
+		{c.Locks, "Locks"},
+		{c.Pausable, "Pausable"},
+		{c.StartPaused, "StartPaused"},
+		{c.Permit, "Permit"},
+		{c.Snapshot, "Snapshot"},
+		{c.SnapshotOnDeploy, "SnapshotOnDeploy"},
+		{c.Votes, "Votes"},
+		{c.RejectSelfTransfer, "RejectSelfTransfer"},
+		{c.ReentrancyGuard, "ReentrancyGuard"},
+	} {
+		if f.on {
+			features = append(features, f.name)
+		}
+	}
+	return features
+}
+
+// ConfigHash returns the hex SHA-256 of the config's canonical JSON form
+// (fields in declaration order), identifying the exact inputs of a build.
+func (c *TokenConfig) ConfigHash() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HasMintSchedule returns true if mint() is limited by a linear emission schedule.
+func (c *TokenConfig) HasMintSchedule() bool {
+	return c.Mintable && c.MintSchedule == MintScheduleLinear
+}
+
+// MintRecipient returns the Solidity expression the initial supply is
+// minted to: the configured holder, else the admin argument, else msg.sender.
+func (c *TokenConfig) MintRecipient() string {
+	switch {
+	case c.InitialHolder != "":
+		return ChecksumAddress(c.InitialHolder)
+	case c.NeedsOwnable():
+		return "initialOwner"
+	case c.NeedsRoles():
+		return "defaultAdmin"
+	default:
+		return "msg.sender"
+	}
+}
+
+// MintsToDeployer returns true if any initial supply goes to the deployer
+// (or the admin argument) rather than an initial holder or allocations.
+func (c *TokenConfig) MintsToDeployer() bool {
+	return c.InitialHolder == "" && len(c.Allocations) == 0
+}
+
+// DeployerHoldsSupply returns true if the deployer ends up holding the
+// initial supply, which the generated tests rely on to fund transfers.
+func (c *TokenConfig) DeployerHoldsSupply() bool {
+	return c.InitialSupply != "" && c.MintsToDeployer()
+}
+
+// HasGuardableFunctions returns true if the contract generates a function
+// ReentrancyGuard marks nonReentrant.
+func (c *TokenConfig) HasGuardableFunctions() bool {
+	return c.Mintable || c.AdminBurn || c.HasBridge() || c.MerkleClaim
+}
+
+// HasBridge returns true if a bridge address gates a mint/burn pair.
+func (c *TokenConfig) HasBridge() bool {
+	return c.BridgeMinter != ""
+}
+
+// BridgeAddress returns the bridge address in EIP-55 checksum form.
+func (c *TokenConfig) BridgeAddress() string {
+	return ChecksumAddress(c.BridgeMinter)
+}
+
+// CompilerVersion returns the solc release at the lower bound of the
+// SolidityVersion pragma, e.g. "0.8.24" for "^0.8.24".
+func (c *TokenConfig) CompilerVersion() string {
+	bound, _, _ := strings.Cut(strings.TrimSpace(c.SolidityVersion), " ")
+	return strings.TrimLeft(bound, "^~>=")
+}
+
+// minRequireErrorSolc is the first solc release accepting a custom error
+// as require()'s second argument outside via-IR.
+const minRequireErrorSolc = "0.8.27"
+
+// versionAtLeast reports whether dotted version v is min or later. A
+// version it cannot read counts as older.
+func versionAtLeast(v, min string) bool {
+	have, want := strings.Split(v, "."), strings.Split(min, ".")
+	for i, w := range want {
+		if i >= len(have) {
+			return false
+		}
+		h, err := strconv.Atoi(have[i])
+		if err != nil {
+			return false
+		}
+		if n, _ := strconv.Atoi(w); h != n {
+			return h > n
+		}
+	}
+	return true
+}
+
+// UsesRequireGuards returns true if checks are written as require()
+// rather than if/revert.
+func (c *TokenConfig) UsesRequireGuards() bool {
+	return c.GuardStyle == GuardRequire || (c.GuardStyle == "" && c.RequireStrings)
+}
+
+// MerkleRootHex returns MerkleRoot as a lowercase bytes32 literal.
+func (c *TokenConfig) MerkleRootHex() string {
+	return strings.ToLower(c.MerkleRoot)
+}
+
+// EmitsEvents returns true if admin actions that OpenZeppelin does not
+// already log (admin burns, balance locks, scheduled mints) declare and emit
+// their own events.
+func (c *TokenConfig) EmitsEvents() bool {
+	return !c.OmitEvents
+}
+
+// LinkedLibraries returns the external libraries the contract must be
+// linked against when ExtractLibraries is set.
+func (c *TokenConfig) LinkedLibraries() []string {
+	if !c.ExtractLibraries {
+		return nil
+	}
+	var libs []string
+	if c.HasMintSchedule() {
+		libs = append(libs, "EmissionSchedule")
+	}
+	return libs
+}
+
+// EmissionRateUnits returns the emission rate in the token's smallest unit
+// per second (EmissionRatePerSecond * 10^Decimals).
+func (c *TokenConfig) EmissionRateUnits() string {
+	if units := c.toUnits(c.EmissionRatePerSecond); units != nil {
+		return units.String()
+	}
+	return ""
+}
+
+// UsesHardhatDeploy returns true if the deploy script targets the
+// hardhat-deploy plugin instead of a plain `hardhat run` script.
+func (c *TokenConfig) UsesHardhatDeploy() bool {
+	return c.DeployStyle == DeployStyleHardhatDeploy
+}
+
 // HasAccessControl returns true if any access control is active.
 func (c *TokenConfig) HasAccessControl() bool {
 	return c.AccessControl != AccessNone
@@ -171,13 +1087,120 @@ func (c *TokenConfig) NeedsOwnable() bool {
 	return c.AccessControl == AccessOwnable
 }
 
+// NeedsEIP712 returns true if Votes needs its EIP712 domain initialized
+// directly, i.e. ERC20Permit is not already providing it.
+func (c *TokenConfig) NeedsEIP712() bool {
+	return c.Votes && !c.Permit
+}
+
+// NeedsNoncesOverride returns true if both ERC20Permit and ERC20Votes
+// inherit Nonces (OZ v5), requiring an explicit nonces() override.
+func (c *TokenConfig) NeedsNoncesOverride() bool {
+	return c.Votes && c.Permit && c.ozVersion() == OZv5
+}
+
+// UsesTimestampClock returns true if Votes checkpoints use block.timestamp.
+func (c *TokenConfig) UsesTimestampClock() bool {
+	return c.Votes && c.ClockMode == ClockTimestamp
+}
+
 // NeedsRoles returns true if AccessControl (roles) should be imported.
 func (c *TokenConfig) NeedsRoles() bool {
 	return c.AccessControl == AccessRoles
 }
 
-// ImportPaths returns all required OpenZeppelin import paths.
+// IsUpgradeable returns true if the token is deployed behind a proxy.
+func (c *TokenConfig) IsUpgradeable() bool {
+	return c.Upgradeable == UpgradeUUPS || c.Upgradeable == UpgradeTransparent
+}
+
+// IsUUPS returns true if the token uses the UUPS proxy pattern.
+func (c *TokenConfig) IsUUPS() bool {
+	return c.Upgradeable == UpgradeUUPS
+}
+
+// OZContract returns the OpenZeppelin contract name for the configured
+// variant, e.g. "ERC20Pausable" becomes "ERC20PausableUpgradeable".
+func (c *TokenConfig) OZContract(name string) string {
+	if c.IsUpgradeable() {
+		return name + "Upgradeable"
+	}
+	return name
+}
+
+// ozImport maps a standard OpenZeppelin import path to the configured variant.
+func (c *TokenConfig) ozImport(path string) string {
+	if !c.IsUpgradeable() {
+		return path
+	}
+	path = strings.Replace(path, ozContractsPrefix, ozUpgradeablePrefix, 1)
+	return strings.TrimSuffix(path, ".sol") + "Upgradeable.sol"
+}
+
+// ImportPaths returns all required import paths: the OpenZeppelin ones,
+// then any ExtraImports not already listed.
 func (c *TokenConfig) ImportPaths() []string {
+	imports := c.baseImportPaths()
+	for i, p := range imports {
+		imports[i] = c.ozImport(p)
+	}
+	if c.IsUpgradeable() {
+		imports = append([]string{ozUpgradeablePrefix + "proxy/utils/Initializable.sol"}, imports...)
+	}
+	if c.IsUUPS() {
+		imports = append(imports, ozUpgradeablePrefix+"proxy/utils/UUPSUpgradeable.sol")
+	}
+	if c.MerkleClaim {
+		// A stateless library: the plain package serves upgradeable tokens too.
+		imports = append(imports, ozContractsPrefix+"utils/cryptography/MerkleProof.sol")
+	}
+	for _, p := range c.ExtraImports {
+		if !slices.Contains(imports, p) {
+			imports = append(imports, p)
+		}
+	}
+	return imports
+}
+
+// ImportGroup is a commented block of imports in the generated contract.
+type ImportGroup struct {
+	Name  string
+	Paths []string
+}
+
+// importCategories orders the ImportGroups blocks; the first path fragment
+// an import contains picks its block, and unmatched paths go last.
+var importCategories = []struct{ name, fragment string }{
+	{"Core", "/token/ERC20/ERC20"},
+	{"Extensions", "/token/ERC20/extensions/"},
+	{"Access control", "/access/"},
+	{"Upgradeability", "/proxy/"}, // before Utilities: proxy/utils/...
+	{"Utilities", "/utils/"},
+}
+
+// ImportGroups returns ImportPaths split into categories (core token,
+// extensions, access control, upgradeability, utilities, then anything
+// else), keeping their order within each group and omitting empty groups.
+func (c *TokenConfig) ImportGroups() []ImportGroup {
+	groups := make([]ImportGroup, len(importCategories)+1)
+	for i, cat := range importCategories {
+		groups[i].Name = cat.name
+	}
+	groups[len(importCategories)].Name = "Additional imports"
+	for _, p := range c.ImportPaths() {
+		i := len(importCategories)
+		for j, cat := range importCategories {
+			if strings.Contains(p, cat.fragment) {
+				i = j
+				break
+			}
+		}
+		groups[i].Paths = append(groups[i].Paths, p)
+	}
+	return slices.DeleteFunc(groups, func(g ImportGroup) bool { return len(g.Paths) == 0 })
+}
+
+func (c *TokenConfig) baseImportPaths() []string {
 	var imports []string
 
 	imports = append(imports, "@openzeppelin/contracts/token/ERC20/ERC20.sol")
@@ -187,7 +1210,11 @@ func (c *TokenConfig) ImportPaths() []string {
 	}
 	if c.Pausable {
 		imports = append(imports, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Pausable.sol")
-		imports = append(imports, "@openzeppelin/contracts/utils/Pausable.sol")
+		if c.OZVersion == OZv4 {
+			imports = append(imports, "@openzeppelin/contracts/security/Pausable.sol")
+		} else {
+			imports = append(imports, "@openzeppelin/contracts/utils/Pausable.sol")
+		}
 	}
 	if c.Permit {
 		imports = append(imports, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Permit.sol")
@@ -195,6 +1222,9 @@ func (c *TokenConfig) ImportPaths() []string {
 	if c.Snapshot {
 		imports = append(imports, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Snapshot.sol")
 	}
+	if c.NeedsEIP712() {
+		imports = append(imports, "@openzeppelin/contracts/utils/cryptography/EIP712.sol")
+	}
 	if c.Votes {
 		imports = append(imports, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Votes.sol")
 	}
@@ -207,12 +1237,76 @@ func (c *TokenConfig) ImportPaths() []string {
 	if c.NeedsRoles() {
 		imports = append(imports, "@openzeppelin/contracts/access/AccessControl.sol")
 	}
+	if c.ReentrancyGuard {
+		if c.OZVersion == OZv4 {
+			imports = append(imports, "@openzeppelin/contracts/security/ReentrancyGuard.sol")
+		} else {
+			imports = append(imports, "@openzeppelin/contracts/utils/ReentrancyGuard.sol")
+		}
+	}
 
 	return imports
 }
 
-// InheritanceList returns the Solidity inheritance list (excluding base ERC20).
+// InheritanceList returns the Solidity inheritance list (excluding base ERC20),
+// ending with any ExtraParents.
 func (c *TokenConfig) InheritanceList() []string {
+	return append(c.generatedParents(), c.ExtraParents...)
+}
+
+// generatedParents returns the inherited contracts erc20gen chooses itself.
+func (c *TokenConfig) generatedParents() []string {
+	list := c.baseContracts()
+	for i, name := range list {
+		list[i] = c.OZContract(name)
+	}
+	if c.IsUUPS() {
+		list = append(list, "UUPSUpgradeable")
+	}
+	return list
+}
+
+// transferHooks maps each OpenZeppelin major version to the ERC20 hooks an
+// extension can override, and the bases that override them. v5 funnels
+// every mint, burn, and transfer through _update; v4 splits the same work
+// across the token-transfer hooks, _mint, and _burn.
+var transferHooks = map[OZVersion]map[string]map[string]bool{
+	OZv4: {
+		"_beforeTokenTransfer": {"ERC20Pausable": true, "ERC20Snapshot": true},
+		"_afterTokenTransfer":  {"ERC20Votes": true},
+		"_mint":                {"ERC20Capped": true, "ERC20Votes": true},
+		"_burn":                {"ERC20Votes": true},
+	},
+	OZv5: {
+		"_update": {"ERC20Capped": true, "ERC20Pausable": true, "ERC20Snapshot": true, "ERC20Votes": true},
+	},
+}
+
+// ozVersion returns the target OpenZeppelin version, defaulting to v5 for
+// configs that have not been through Validate.
+func (c *TokenConfig) ozVersion() OZVersion {
+	if c.OZVersion == "" {
+		return OZv5
+	}
+	return c.OZVersion
+}
+
+// TransferCheckHook returns the hook the token itself overrides to check
+// transfers (balance locks, the self-transfer guard): _update on v5,
+// _beforeTokenTransfer on v4.
+func (c *TokenConfig) TransferCheckHook() string {
+	if c.ozVersion() == OZv4 {
+		return "_beforeTokenTransfer"
+	}
+	return "_update"
+}
+
+// baseContracts returns the non-upgradeable names of the inherited
+// OpenZeppelin bases (excluding ERC20), in inheritance order. The order is
+// load-bearing for _update: the last base runs first, so ERC20Votes stays
+// after ERC20Pausable and moves checkpoints only once the pause check and
+// the balance change have gone through.
+func (c *TokenConfig) baseContracts() []string {
 	var list []string
 
 	if c.MaxSupply != "" {
@@ -230,6 +1324,9 @@ func (c *TokenConfig) InheritanceList() []string {
 	if c.Snapshot {
 		list = append(list, "ERC20Snapshot")
 	}
+	if c.NeedsEIP712() {
+		list = append(list, "EIP712")
+	}
 	if c.Votes {
 		list = append(list, "ERC20Votes")
 	}
@@ -239,6 +1336,60 @@ func (c *TokenConfig) InheritanceList() []string {
 	if c.NeedsRoles() {
 		list = append(list, "AccessControl")
 	}
+	if c.ReentrancyGuard {
+		list = append(list, "ReentrancyGuard")
+	}
+	return list
+}
 
+// HookOverrides returns ERC20 plus every inherited base that defines hook
+// in the target OpenZeppelin version, in inheritance order, for the
+// override(...) specifier. Deriving it from baseContracts keeps the clause
+// in step with the "is" list.
+func (c *TokenConfig) HookOverrides(hook string) []string {
+	bases := transferHooks[c.ozVersion()][hook]
+	list := []string{c.OZContract("ERC20")}
+	for _, name := range c.baseContracts() {
+		if bases[name] {
+			list = append(list, c.OZContract(name))
+		}
+	}
 	return list
-}
\ No newline at end of file
+}
+
+// NeedsHookOverride returns true if more than one base defines hook, which
+// Solidity requires the token to resolve with a single override, or if
+// hook is the TransferCheckHook and the token itself checks or rescales
+// transfers.
+// It is false for hooks that do not exist in the target version.
+func (c *TokenConfig) NeedsHookOverride(hook string) bool {
+	if _, ok := transferHooks[c.ozVersion()][hook]; !ok {
+		return false
+	}
+	if hook == c.TransferCheckHook() && (c.Locks || c.RejectSelfTransfer || c.BurnRebase) {
+		return true
+	}
+	return len(c.HookOverrides(hook)) > 1
+}
+
+// OverriddenHooks returns every hook the contract overrides, sorted.
+func (c *TokenConfig) OverriddenHooks() []string {
+	var hooks []string
+	for hook := range transferHooks[c.ozVersion()] {
+		if c.NeedsHookOverride(hook) {
+			hooks = append(hooks, hook)
+		}
+	}
+	slices.Sort(hooks)
+	return hooks
+}
+
+// UpdateOverrides returns the override(...) list for _update.
+func (c *TokenConfig) UpdateOverrides() []string {
+	return c.HookOverrides("_update")
+}
+
+// NeedsUpdateOverride returns true if the contract overrides _update.
+func (c *TokenConfig) NeedsUpdateOverride() bool {
+	return c.NeedsHookOverride("_update")
+}
@@ -0,0 +1,44 @@
+package config
+
+// Constraint is a declarative limit Validate enforces on a TokenConfig field
+// beyond its Go type. Zero values mean "no limit".
+type Constraint struct {
+	Required bool
+	Pattern  string // regular expression a string (or each list item) must match
+	Minimum  *int64
+	Maximum  *int64
+	Enum     []string // allowed values of a plain string field
+}
+
+// FieldConstraints returns the constraints of each TokenConfig field, keyed
+// by Go field name. Enum-typed fields describe themselves through Values.
+func FieldConstraints() map[string]Constraint {
+	zero, one, maxDecimals := int64(0), int64(1), int64(MaxDecimals)
+	maxDelay, maxPeriod, hundred := int64(maxVotingDelay), int64(maxVotingPeriod), int64(100)
+	networks := make([]string, len(KnownNetworks))
+	for i, n := range KnownNetworks {
+		networks[i] = n.Name
+	}
+	return map[string]Constraint{
+		"Name":                  {Required: true, Pattern: validNameRe.String()},
+		"Symbol":                {Required: true, Pattern: validSymbolRe.String()},
+		"Decimals":              {Minimum: &zero, Maximum: &maxDecimals},
+		"InitialSupply":         {Pattern: validDecimalNum.String()},
+		"MaxSupply":             {Pattern: validDecimalNum.String()},
+		"EmissionRatePerSecond": {Pattern: validDecimalNum.String()},
+		"EmissionStart":         {Minimum: &zero},
+		"GovernorVotingDelay":   {Minimum: &zero, Maximum: &maxDelay},
+		"GovernorVotingPeriod":  {Minimum: &one, Maximum: &maxPeriod},
+		"GovernorQuorumPercent": {Minimum: &one, Maximum: &hundred},
+		"InitialHolder":         {Pattern: addressRe.String()},
+		"BridgeMinter":          {Pattern: addressRe.String()},
+		"AdminAddress":          {Pattern: addressRe.String()},
+		"MerkleRoot":            {Pattern: merkleRootRe.String()},
+		"MetadataURI":           {Pattern: metadataURIRe.String()},
+		"Allocations":           {Pattern: `^0x[0-9a-fA-F]{40}=\d+$`},
+		"ExtraImports":          {Pattern: extraImportRe.String()},
+		"ExtraParents":          {Pattern: identifierRe.String()},
+		"Network":               {Enum: networks},
+		"Indent":                {Pattern: validIndentRe.String()},
+	}
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CtorParam is an extra constructor parameter stored in an immutable.
+type CtorParam struct {
+	Type string
+	Name string
+}
+
+// Only value types can be immutable, so string/bytes/arrays are rejected.
+var ctorParamRe = regexp.MustCompile(`^(address|bool|u?int(\d{0,3})|bytes(\d{1,2}))\s+([A-Za-z_][A-Za-z0-9_]*)$`)
+
+// reservedCtorNames collide with generated parameters, ERC-20 functions, or
+// deploy-script variables.
+var reservedCtorNames = map[string]bool{
+	"initialOwner": true, "defaultAdmin": true,
+	"name": true, "symbol": true, "decimals": true, "totalSupply": true,
+	"deployer": true, "token": true, "address": true, "owner": true,
+}
+
+// exposedParamNames are the immutables ExposeParams declares.
+var exposedParamNames = map[string]bool{"initialSupply": true, "maxSupply": true}
+
+// ExposesParams reports whether the contract declares the ExposeParams
+// immutables: it needs an initial supply or a cap to record.
+func (c *TokenConfig) ExposesParams() bool {
+	return c.ExposeParams && (c.InitialSupply != "" || c.MaxSupply != "")
+}
+
+// parseCtorParam parses "<type> <name>", e.g. "address treasury".
+func parseCtorParam(s string) (CtorParam, error) {
+	m := ctorParamRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return CtorParam{}, fmt.Errorf("%q must be \"<type> <name>\" with a value type (address, bool, uintN, intN, bytesN)", s)
+	}
+	if bits := m[2]; bits != "" {
+		if n, _ := strconv.Atoi(bits); n == 0 || n > 256 || n%8 != 0 {
+			return CtorParam{}, fmt.Errorf("%q: integer size must be a multiple of 8 between 8 and 256", s)
+		}
+	}
+	if size := m[3]; size != "" {
+		if n, _ := strconv.Atoi(size); n == 0 || n > 32 {
+			return CtorParam{}, fmt.Errorf("%q: bytes size must be between 1 and 32", s)
+		}
+	}
+	if reservedCtorNames[m[4]] {
+		return CtorParam{}, fmt.Errorf("%q: name %q is reserved", s, m[4])
+	}
+	return CtorParam{Type: m[1], Name: m[4]}, nil
+}
+
+// CtorParams returns the parsed ExtraConstructorParams, skipping any that
+// fail to parse (Validate reports those).
+func (c *TokenConfig) CtorParams() []CtorParam {
+	var params []CtorParam
+	for _, s := range c.ExtraConstructorParams {
+		if p, err := parseCtorParam(s); err == nil {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// Placeholder returns a zero-value JS literal for the parameter, used in
+// generated deploy scripts and tests until the user fills in a real value.
+func (p CtorParam) Placeholder() string {
+	switch {
+	case p.Type == "address":
+		return `"0x0000000000000000000000000000000000000000"`
+	case p.Type == "bool":
+		return "false"
+	case strings.HasPrefix(p.Type, "bytes"):
+		n, _ := strconv.Atoi(strings.TrimPrefix(p.Type, "bytes"))
+		return `"0x` + strings.Repeat("00", n) + `"`
+	default:
+		return "0n"
+	}
+}
+
+// SolidityZero returns the parameter type's zero value as a Solidity
+// expression, used where generated Solidity tests deploy the token.
+func (p CtorParam) SolidityZero() string {
+	switch {
+	case p.Type == "address":
+		return "address(0)"
+	case p.Type == "bool":
+		return "false"
+	case strings.HasPrefix(p.Type, "bytes"):
+		return p.Type + "(0)"
+	default:
+		return "0"
+	}
+}
+
+// ConstructorArgs returns the JS expressions passed to the constructor by
+// the deploy script, e.g. ["deployer.address", "treasury"]. Extra params
+// refer to placeholder constants the script declares.
+func (c *TokenConfig) ConstructorArgs() []string {
+	if c.IsUpgradeable() {
+		return nil
+	}
+	return c.DeployArgs(c.DeployAdmin(), false)
+}
+
+// DeployArgs returns constructor arguments with admin as the owner/admin
+// expression. With inline set, extra params are their placeholder literals
+// instead of variable names.
+func (c *TokenConfig) DeployArgs(admin string, inline bool) []string {
+	var args []string
+	if c.HasAccessControl() {
+		args = append(args, admin)
+	}
+	for _, p := range c.CtorParams() {
+		if inline {
+			args = append(args, p.Placeholder())
+		} else {
+			args = append(args, p.Name)
+		}
+	}
+	return args
+}
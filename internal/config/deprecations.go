@@ -0,0 +1,35 @@
+package config
+
+// deprecationKey identifies a generated construct (an inherited
+// OpenZeppelin contract or an overridden hook) in one OZ major version.
+type deprecationKey struct {
+	Feature string
+	OZ      OZVersion
+}
+
+// deprecations is the single deprecation matrix. Lenient mode prints these
+// as warnings; --strict turns them into validation errors. Every row must
+// name something usedConstructs can return for that version: the transfer
+// hooks follow transferHooks, so only inherited bases can land here.
+var deprecations = map[deprecationKey]string{
+	{"ERC20Snapshot", OZv5}: "ERC20Snapshot was removed in OpenZeppelin v5 — use ERC20Votes checkpoints (getPastVotes, getPastTotalSupply) or --oz-version 4",
+}
+
+// usedConstructs lists every deprecation-matrix feature the contract
+// would use: its OpenZeppelin bases and the transfer hooks the template
+// overrides for the target version.
+func (c *TokenConfig) usedConstructs() []string {
+	return append(c.baseContracts(), c.OverriddenHooks()...)
+}
+
+// Deprecations returns a message for each construct the config would
+// generate that is deprecated or removed in its target OpenZeppelin version.
+func (c *TokenConfig) Deprecations() []string {
+	var msgs []string
+	for _, f := range c.usedConstructs() {
+		if msg, ok := deprecations[deprecationKey{f, c.ozVersion()}]; ok {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
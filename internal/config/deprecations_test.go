@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationMatrixIsWellFormed(t *testing.T) {
+	for key, msg := range deprecations {
+		assert.Contains(t, []OZVersion{OZv4, OZv5}, key.OZ, "%s: unknown OZ version", key.Feature)
+		assert.NotEmpty(t, key.Feature)
+		assert.NotEmpty(t, msg, "%s on v%s has no message", key.Feature, key.OZ)
+
+		// A row nothing can emit is dead: it must be an inherited base or
+		// a hook the template overrides in that version.
+		_, base := FeatureDescriptors[key.Feature]
+		_, hook := transferHooks[key.OZ][key.Feature]
+		assert.True(t, base || hook, "%s on v%s is never generated", key.Feature, key.OZ)
+	}
+}
+
+func TestUsedConstructsFollowTheEmittedHooks(t *testing.T) {
+	tests := []struct {
+		oz    OZVersion
+		hooks []string
+	}{
+		{OZv4, []string{"_beforeTokenTransfer"}},
+		{OZv5, []string{"_update"}},
+	}
+	for _, tt := range tests {
+		t.Run("v"+string(tt.oz), func(t *testing.T) {
+			cfg := &TokenConfig{Name: "Pause", Symbol: "PSE", Decimals: 18, Pausable: true, Locks: true, OZVersion: tt.oz, Strict: true}
+			require.NoError(t, cfg.Validate(), "pausable hooks are current on v%s", tt.oz)
+			assert.Equal(t, tt.hooks, cfg.OverriddenHooks())
+			assert.Subset(t, cfg.usedConstructs(), tt.hooks)
+			assert.Empty(t, cfg.Deprecations())
+		})
+	}
+}
+
+func TestStrictRejectsWhatLenientWarns(t *testing.T) {
+	newCfg := func() *TokenConfig {
+		return &TokenConfig{Name: "Snap", Symbol: "SNP", Decimals: 18, Snapshot: true, OZVersion: OZv5}
+	}
+
+	lenient := newCfg()
+	require.NoError(t, lenient.Validate())
+	require.Len(t, lenient.Deprecations(), 1)
+	assert.Contains(t, lenient.Deprecations()[0], "ERC20Snapshot was removed in OpenZeppelin v5")
+
+	strict := newCfg()
+	strict.Strict = true
+	err := strict.Validate()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "Strict", verr.Fields[0].Field)
+
+	v4 := newCfg()
+	v4.Strict = true
+	v4.OZVersion = OZv4
+	assert.NoError(t, v4.Validate(), "Snapshot is current on v4")
+}
@@ -0,0 +1,28 @@
+package config
+
+import "strings"
+
+// FieldError is a single validation failure tied to a TokenConfig field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError aggregates every FieldError found by Validate. Error()
+// keeps the newline-delimited form the CLI prints; programmatic callers can
+// use errors.As and read Fields instead.
+type ValidationError struct {
+	Fields []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+	}
+	return strings.Join(msgs, "\n  - ")
+}
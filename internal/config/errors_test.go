@@ -0,0 +1,47 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_ReturnsStructuredErrors(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "Bad Token!", Symbol: "ba-d", Decimals: 80}
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	var verr *config.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, []config.FieldError{
+		{Field: "Name", Message: "token name must be 1-64 alphanumeric characters (spaces, hyphens, underscores allowed)"},
+		{Field: "Symbol", Message: "token symbol must be 1-11 uppercase letters/digits (e.g. MTK, USDC)"},
+		{Field: "Decimals", Message: "decimals must be between 0 and 77 — 10**80 overflows uint256"},
+	}, verr.Fields)
+
+	assert.Equal(t,
+		"token name must be 1-64 alphanumeric characters (spaces, hyphens, underscores allowed)"+
+			"\n  - token symbol must be 1-11 uppercase letters/digits (e.g. MTK, USDC)"+
+			"\n  - decimals must be between 0 and 77 — 10**80 overflows uint256",
+		err.Error())
+}
+
+func TestValidationError_JSON(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "Token", Symbol: "TKN", Network: "goerli"}
+	err := cfg.Validate()
+
+	var verr *config.ValidationError
+	require.True(t, errors.As(err, &verr))
+	data, jerr := json.Marshal(verr)
+	require.NoError(t, jerr)
+	assert.JSONEq(t, `{"errors":[{"field":"Network","message":"unknown network \"goerli\" — must be one of: mainnet, sepolia, polygon, arbitrum, custom"}]}`, string(data))
+}
+
+func TestValidate_ValidConfigReturnsNil(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "Token", Symbol: "TKN", Decimals: 18}
+	assert.NoError(t, cfg.Validate())
+}
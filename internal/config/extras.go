@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// extraImportRe accepts package and relative Solidity paths such as
+	// "@openzeppelin/contracts/token/ERC20/extensions/ERC20FlashMint.sol"
+	// or "./extensions/Taxed.sol"; quotes and whitespace would break the
+	// generated import statement.
+	extraImportRe = regexp.MustCompile(`^[A-Za-z0-9@._/\-]+\.sol$`)
+
+	// identifierRe matches a Solidity identifier.
+	identifierRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// validateExtras checks ExtraImports and ExtraParents. It runs after the
+// feature flags are settled so parents already inherited are detected.
+func (c *TokenConfig) validateExtras(errs *ValidationError) {
+	for _, p := range c.ExtraImports {
+		if !extraImportRe.MatchString(p) {
+			errs.add("ExtraImports", fmt.Sprintf("%q is not a Solidity import path — must end in .sol and contain only letters, digits, @ . _ / -", p))
+		}
+	}
+
+	inherited := map[string]bool{c.OZContract("ERC20"): true}
+	if c.IsUpgradeable() {
+		inherited["Initializable"] = true
+	}
+	for _, name := range c.generatedParents() {
+		inherited[name] = true
+	}
+	seen := map[string]bool{}
+	for _, name := range c.ExtraParents {
+		switch {
+		case !identifierRe.MatchString(name):
+			errs.add("ExtraParents", fmt.Sprintf("%q is not a valid Solidity identifier", name))
+		case inherited[name]:
+			errs.add("ExtraParents", fmt.Sprintf("%s is already inherited", name))
+		case seen[name]:
+			errs.add("ExtraParents", fmt.Sprintf("duplicate extra parent %q", name))
+		}
+		seen[name] = true
+	}
+}
+
+// extrasWarning returns the caveat printed when ExtraParents is set, or "".
+func (c *TokenConfig) extrasWarning() string {
+	if len(c.ExtraParents) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("extra parents (%s) are inherited as-is — any overrides they require (_update, nonces, supportsInterface, ...) and their constructor arguments are your responsibility", strings.Join(c.ExtraParents, ", "))
+}
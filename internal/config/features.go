@@ -0,0 +1,106 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// FeatureDescriptor documents one OpenZeppelin building block a generated
+// token can inherit. It drives both `list-features` and `--explain`.
+type FeatureDescriptor struct {
+	Flag    string // generate flag that pulls the contract in ("" = always / implied)
+	Summary string // one-line description for list-features
+	Explain string // inline comment emitted with --explain
+}
+
+// FeatureDescriptors is keyed by the non-upgradeable contract name.
+var FeatureDescriptors = map[string]FeatureDescriptor{
+	"ERC20": {
+		Summary: "Core ERC-20 balances, transfers, and allowances",
+		Explain: "core balances, transfers, and allowances; every mint, burn, and transfer goes through _update",
+	},
+	"ERC20Burnable": {
+		Flag:    "--burnable",
+		Summary: "Holders can burn their own tokens",
+		Explain: "adds burn() and burnFrom() so holders can destroy their own tokens",
+	},
+	"ERC20Pausable": {
+		Flag:    "--pausable",
+		Summary: "Emergency pause of all transfers",
+		Explain: "overrides _update to block transfers while paused",
+	},
+	"ERC20Permit": {
+		Flag:    "--permit",
+		Summary: "EIP-2612 gasless approvals via signatures",
+		Explain: "adds EIP-2612 permit() so approvals can be signed off-chain",
+	},
+	"ERC20Snapshot": {
+		Flag:    "--snapshot",
+		Summary: "Balance snapshots for governance",
+		Explain: "overrides _update to record balances at each snapshot id",
+	},
+	"EIP712": {
+		Flag:    "--votes",
+		Summary: "Typed-data signing domain (implied by Votes without Permit)",
+		Explain: "typed-data domain used to verify delegateBySig signatures",
+	},
+	"ERC20Votes": {
+		Flag:    "--votes",
+		Summary: "On-chain voting power with delegation (EIP-5805)",
+		Explain: "overrides _update to move voting power checkpoints on every transfer",
+	},
+	"ERC20Capped": {
+		Flag:    "--max-supply",
+		Summary: "Hard cap on total supply",
+		Explain: "overrides _update to revert mints that would exceed cap()",
+	},
+	"Ownable": {
+		Flag:    "--access ownable",
+		Summary: "Single owner guards privileged functions",
+		Explain: "a single owner account guards privileged functions via onlyOwner",
+	},
+	"AccessControl": {
+		Flag:    "--access roles",
+		Summary: "Role-based permissions (MINTER_ROLE, PAUSER_ROLE, ...)",
+		Explain: "role-based permissions; privileged functions check onlyRole",
+	},
+	"ReentrancyGuard": {
+		Flag:    "--reentrancy-guard",
+		Summary: "nonReentrant on generated mint, burnFrom, bridge and claim functions",
+		Explain: "adds the nonReentrant modifier guarding mint, burnFrom, bridge and claim functions",
+	},
+	"Initializable": {
+		Flag:    "--upgradeable",
+		Summary: "One-time initialize() replacing the constructor behind a proxy",
+		Explain: "replaces the constructor with a one-time initialize() for proxies",
+	},
+	"UUPSUpgradeable": {
+		Flag:    "--upgradeable uups",
+		Summary: "Upgrade logic lives in the token, gated by _authorizeUpgrade",
+		Explain: "upgrade logic lives in the implementation, gated by _authorizeUpgrade",
+	},
+}
+
+// FeatureNames returns the FeatureDescriptors keys in sorted order.
+func FeatureNames() []string {
+	names := make([]string, 0, len(FeatureDescriptors))
+	for name := range FeatureDescriptors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExplainContract returns the --explain comment text for contract, which
+// may be an upgradeable variant (e.g. ERC20PausableUpgradeable).
+func ExplainContract(contract string) string {
+	base := contract
+	if contract != "UUPSUpgradeable" {
+		base = strings.TrimSuffix(contract, "Upgradeable")
+	}
+	d, ok := FeatureDescriptors[base]
+	if !ok {
+		return ""
+	}
+	return contract + ": " + d.Explain
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// Governor defaults match the OpenZeppelin Wizard: a 1 day voting delay and
+// 1 week voting period at 12s blocks, and a 4% quorum.
+const (
+	DefaultVotingDelay   = 7200
+	DefaultVotingPeriod  = 50400
+	DefaultQuorumPercent = 4
+
+	// GovernorSettings stores the delay as uint48 and the period as uint32.
+	maxVotingDelay  = 1<<48 - 1
+	maxVotingPeriod = math.MaxUint32
+)
+
+// GovernorName returns the companion Governor contract's name.
+func (c *TokenConfig) GovernorName() string {
+	return c.SafeName() + "Governor"
+}
+
+// GovernorFileName returns the companion Governor's Solidity filename.
+func (c *TokenConfig) GovernorFileName() string {
+	return c.GovernorName() + ".sol"
+}
+
+// validateGovernor checks the companion Governor's settings.
+func (c *TokenConfig) validateGovernor(errs *ValidationError) {
+	if c.GovernorVotingDelay < 0 || c.GovernorVotingDelay > maxVotingDelay {
+		errs.add("GovernorVotingDelay", fmt.Sprintf("voting delay %d must be between 0 and %d", c.GovernorVotingDelay, int64(maxVotingDelay)))
+	}
+	if c.GovernorVotingPeriod <= 0 || c.GovernorVotingPeriod > maxVotingPeriod {
+		errs.add("GovernorVotingPeriod", fmt.Sprintf("voting period %d must be between 1 and %d — GovernorSettings rejects an empty period", c.GovernorVotingPeriod, int64(maxVotingPeriod)))
+	}
+	if c.GovernorQuorumPercent == 0 || c.GovernorQuorumPercent > 100 {
+		errs.add("GovernorQuorumPercent", fmt.Sprintf("quorum %d%% must be between 1 and 100", c.GovernorQuorumPercent))
+	}
+}
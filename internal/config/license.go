@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LicenseType is an SPDX identifier accepted for the generated contract's
+// license header. See https://spdx.org/licenses/.
+type LicenseType string
+
+const (
+	LicenseMIT          LicenseType = "MIT"
+	LicenseApache20     LicenseType = "Apache-2.0"
+	LicenseGPL20Only    LicenseType = "GPL-2.0-only"
+	LicenseGPL20OrLater LicenseType = "GPL-2.0-or-later"
+	LicenseGPL30        LicenseType = "GPL-3.0"
+	LicenseGPL30Only    LicenseType = "GPL-3.0-only"
+	LicenseGPL30OrLater LicenseType = "GPL-3.0-or-later"
+	LicenseLGPL21Only   LicenseType = "LGPL-2.1-only"
+	LicenseLGPL30       LicenseType = "LGPL-3.0"
+	LicenseLGPL30Only   LicenseType = "LGPL-3.0-only"
+	LicenseAGPL30       LicenseType = "AGPL-3.0"
+	LicenseAGPL30Only   LicenseType = "AGPL-3.0-only"
+	LicenseBSD2Clause   LicenseType = "BSD-2-Clause"
+	LicenseBSD3Clause   LicenseType = "BSD-3-Clause"
+	LicenseMPL20        LicenseType = "MPL-2.0"
+	LicenseISC          LicenseType = "ISC"
+	LicenseBUSL11       LicenseType = "BUSL-1.1"
+	LicenseCC010        LicenseType = "CC0-1.0"
+	LicenseUnlicense    LicenseType = "Unlicense"
+
+	// LicenseUnlicensed is Solidity's keyword for "no license granted". It
+	// is not an SPDX identifier, and must not be confused with the SPDX
+	// Unlicense (a public-domain dedication), so it is matched on its own.
+	LicenseUnlicensed LicenseType = "UNLICENSED"
+)
+
+// KnownLicenses lists every LicenseType constant.
+var KnownLicenses = []LicenseType{
+	LicenseMIT,
+	LicenseApache20,
+	LicenseGPL20Only,
+	LicenseGPL20OrLater,
+	LicenseGPL30,
+	LicenseGPL30Only,
+	LicenseGPL30OrLater,
+	LicenseLGPL21Only,
+	LicenseLGPL30,
+	LicenseLGPL30Only,
+	LicenseAGPL30,
+	LicenseAGPL30Only,
+	LicenseBSD2Clause,
+	LicenseBSD3Clause,
+	LicenseMPL20,
+	LicenseISC,
+	LicenseBUSL11,
+	LicenseCC010,
+	LicenseUnlicense,
+	LicenseUnlicensed,
+}
+
+// Values lists the valid LicenseType values.
+func (LicenseType) Values() []string {
+	values := make([]string, len(KnownLicenses))
+	for i, l := range KnownLicenses {
+		values[i] = string(l)
+	}
+	return values
+}
+
+// String returns the SPDX identifier, as written in the license header.
+func (l LicenseType) String() string {
+	return string(l)
+}
+
+// maxSuggestionDistance is the largest edit distance at which an unknown
+// license is still considered a typo of a known one.
+const maxSuggestionDistance = 3
+
+// normalizeLicense returns the canonical spelling of a known license,
+// matched case-insensitively (e.g. "mit" → "MIT"), or an error naming the
+// closest known license.
+func normalizeLicense(license LicenseType) (LicenseType, error) {
+	license = LicenseType(strings.TrimSpace(string(license)))
+	if equalFoldASCII(string(license), string(LicenseUnlicensed)) {
+		return LicenseUnlicensed, nil
+	}
+	for _, l := range KnownLicenses {
+		if l != LicenseUnlicensed && equalFoldASCII(string(l), string(license)) {
+			return l, nil
+		}
+	}
+	if suggestion := closestLicense(license); suggestion != "" {
+		return "", fmt.Errorf("unknown SPDX license %q — did you mean %q?", license, suggestion)
+	}
+	return "", fmt.Errorf("unknown SPDX license %q — see https://spdx.org/licenses/ for valid identifiers", license)
+}
+
+// equalFoldASCII reports whether a and b are equal under ASCII case folding.
+// Unlike strings.EqualFold it never matches non-ASCII look-alikes (such as
+// the long s "ſ" for "s"), so the result cannot depend on Unicode rules.
+func equalFoldASCII(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if 'A' <= x && x <= 'Z' {
+			x += 'a' - 'A'
+		}
+		if 'A' <= y && y <= 'Z' {
+			y += 'a' - 'A'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// closestLicense returns the known license with the smallest
+// case-insensitive edit distance to license, or "" if none is close enough.
+func closestLicense(license LicenseType) LicenseType {
+	best, bestDist := LicenseType(""), maxSuggestionDistance+1
+	for _, l := range KnownLicenses {
+		if d := levenshtein(strings.ToLower(string(license)), strings.ToLower(string(l))); d < bestDist {
+			best, bestDist = l, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// NetworkInfo describes a deployment target the deploy script knows about.
+type NetworkInfo struct {
+	Name     string
+	ChainID  uint64 // 0 = not checked (custom networks)
+	Explorer string // block explorer used by `hardhat verify`
+	GasNote  string
+}
+
+// KnownNetworks lists the --network values the deploy script supports.
+var KnownNetworks = []NetworkInfo{
+	{Name: "mainnet", ChainID: 1, Explorer: "Etherscan", GasNote: "Mainnet gas is expensive — check current base fees before deploying."},
+	{Name: "sepolia", ChainID: 11155111, Explorer: "Etherscan (Sepolia)", GasNote: "Testnet ETH is free — use a faucet if the balance is low."},
+	{Name: "polygon", ChainID: 137, Explorer: "Polygonscan", GasNote: "Polygon enforces a minimum priority fee — underpriced transactions stall."},
+	{Name: "arbitrum", ChainID: 42161, Explorer: "Arbiscan", GasNote: "Arbitrum fees include L1 calldata costs; gas limits look higher than on L1."},
+	{Name: "custom", Explorer: "the explorer configured in hardhat.config.js", GasNote: "Confirm the network's fee model in hardhat.config.js before deploying."},
+}
+
+// NetworkInfo returns the descriptor for the configured network, or nil if
+// the deploy script is network-agnostic.
+func (c *TokenConfig) NetworkInfo() *NetworkInfo {
+	for i := range KnownNetworks {
+		if KnownNetworks[i].Name == c.Network {
+			return &KnownNetworks[i]
+		}
+	}
+	return nil
+}
+
+// NetworkParams are the per-network constructor values of a multi-network
+// deploy script, keyed by Hardhat network name in TokenConfig.NetworkConfigs.
+type NetworkParams struct {
+	Owner    string `mapstructure:"owner"`    // initial owner/admin ("" = deployer)
+	Treasury string `mapstructure:"treasury"` // value of an "address treasury" ctor param
+}
+
+// networkNameRe matches a Hardhat network name as used in hardhat.config.js.
+var networkNameRe = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// validateNetworkConfigs checks each network's name and addresses, and that
+// treasuries line up with an "address treasury" constructor parameter.
+func (c *TokenConfig) validateNetworkConfigs(errs *ValidationError) {
+	if c.Network != "" {
+		errs.add("NetworkConfigs", "networks conflict with --network — the multi-network deploy script picks its parameters by network name at run time")
+	}
+	if c.DeployStyle == DeployStyleHardhatDeploy {
+		errs.add("NetworkConfigs", "networks are only supported by the ethers deploy script")
+	}
+
+	hasTreasury := false
+	for _, p := range c.CtorParams() {
+		if p.Name == "treasury" && p.Type == "address" {
+			hasTreasury = true
+		}
+	}
+
+	for _, name := range c.NetworkNames() {
+		p := c.NetworkConfigs[name]
+		if !networkNameRe.MatchString(name) {
+			errs.add("NetworkConfigs", fmt.Sprintf("%q is not a valid network name — use the name from hardhat.config.js", name))
+		}
+		if p.Owner != "" {
+			if !c.HasAccessControl() {
+				errs.add("NetworkConfigs", fmt.Sprintf("network %s: owner requires access control", name))
+			} else if err := validateAddress(p.Owner); err != nil {
+				errs.add("NetworkConfigs", fmt.Sprintf("network %s: owner: %s", name, err))
+			}
+		}
+		switch {
+		case p.Treasury == "" && hasTreasury:
+			errs.add("NetworkConfigs", fmt.Sprintf("network %s: treasury is required by the \"address treasury\" constructor param", name))
+		case p.Treasury != "" && !hasTreasury:
+			errs.add("NetworkConfigs", fmt.Sprintf("network %s: treasury requires --ctor-param \"address treasury\"", name))
+		case p.Treasury != "":
+			if err := validateAddress(p.Treasury); err != nil {
+				errs.add("NetworkConfigs", fmt.Sprintf("network %s: treasury: %s", name, err))
+			}
+		}
+	}
+}
+
+// NetworkNames returns the keys of NetworkConfigs in sorted order.
+func (c *TokenConfig) NetworkNames() []string {
+	names := make([]string, 0, len(c.NetworkConfigs))
+	for name := range c.NetworkConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DeployAdmin returns the deploy-script expression passed as the initial
+// owner/admin: the per-network owner when NetworkConfigs is set, otherwise
+// AdminArg with the deployer as fallback.
+func (c *TokenConfig) DeployAdmin() string {
+	if len(c.NetworkConfigs) > 0 {
+		return "owner"
+	}
+	return c.AdminArg("deployer.address")
+}
+
+// AdminArg returns the JavaScript expression for the roles admin: the quoted
+// AdminAddress in checksum form when set, otherwise fallback.
+func (c *TokenConfig) AdminArg(fallback string) string {
+	if c.AdminAddress == "" {
+		return fallback
+	}
+	return `"` + ChecksumAddress(c.AdminAddress) + `"`
+}
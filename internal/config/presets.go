@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PresetImmutable is a fixed-supply token with no owner and no admin powers.
+const PresetImmutable = "immutable"
+
+// presets populate a TokenConfig before Validate runs. Each one rejects
+// options that contradict it rather than silently overriding them.
+var presets = map[string]func(c *TokenConfig) error{
+	PresetImmutable: applyImmutable,
+}
+
+// PresetNames returns the accepted --preset values in sorted order.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyPreset populates c according to the named preset.
+func ApplyPreset(c *TokenConfig, name string) error {
+	apply, ok := presets[name]
+	if !ok {
+		return fmt.Errorf("unknown preset %q — must be one of: %s", name, strings.Join(PresetNames(), ", "))
+	}
+	return apply(c)
+}
+
+// applyImmutable forces access=none and forbids every feature that would
+// let anyone change supply, balances, or code after deployment. The full
+// supply is minted to the deployer in the constructor.
+func applyImmutable(c *TokenConfig) error {
+	var errs []string
+	for _, f := range []struct {
+		on   bool
+		flag string
+	}{
+		{c.Mintable, "--mintable"},
+		{c.AdminBurn, "--admin-burn"},
+		{c.Locks, "--with-locks"},
+		{c.Pausable, "--pausable"},
+		{c.StartPaused, "--start-paused"},
+		{c.MintSchedule != "" && c.MintSchedule != MintScheduleNone, "--mint-schedule"},
+		{c.IsUpgradeable(), "--upgradeable"},
+		{c.AccessControl != "" && c.AccessControl != AccessNone, "--access"},
+	} {
+		if f.on {
+			errs = append(errs, fmt.Sprintf("%s conflicts with the immutable preset", f.flag))
+		}
+	}
+	if strings.TrimSpace(c.InitialSupply) == "" {
+		errs = append(errs, "the immutable preset requires an initial supply — it is the entire fixed supply")
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n  - "))
+	}
+
+	c.AccessControl = AccessNone
+	c.Upgradeable = UpgradeNone
+	c.MintSchedule = MintScheduleNone
+	return nil
+}
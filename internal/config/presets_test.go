@@ -0,0 +1,37 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPreset_Immutable(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "Fixed", Symbol: "FIX", Decimals: 18, InitialSupply: "21000000", Burnable: true, Permit: true}
+	require.NoError(t, config.ApplyPreset(cfg, config.PresetImmutable))
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, config.AccessNone, cfg.AccessControl)
+	assert.Equal(t, config.UpgradeNone, cfg.Upgradeable)
+	assert.False(t, cfg.Mintable)
+	assert.False(t, cfg.Pausable)
+	assert.True(t, cfg.Burnable, "holder burns don't need an owner")
+}
+
+func TestApplyPreset_ImmutableRejectsConflicts(t *testing.T) {
+	cfg := &config.TokenConfig{Name: "Fixed", Symbol: "FIX", Mintable: true, Pausable: true, AccessControl: config.AccessRoles}
+	err := config.ApplyPreset(cfg, config.PresetImmutable)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--mintable conflicts with the immutable preset")
+	assert.Contains(t, err.Error(), "--pausable conflicts")
+	assert.Contains(t, err.Error(), "--access conflicts")
+	assert.Contains(t, err.Error(), "requires an initial supply")
+}
+
+func TestApplyPreset_Unknown(t *testing.T) {
+	err := config.ApplyPreset(&config.TokenConfig{}, "degen")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown preset "degen"`)
+}
@@ -0,0 +1,17 @@
+package config
+
+import "fmt"
+
+// DefaultBurnRebaseMaxBps caps a single applyBurnRebase() call at 10% of
+// every balance.
+const DefaultBurnRebaseMaxBps = 1000
+
+// bpsDenominator is 100% in basis points; a rebase may not burn all of it.
+const bpsDenominator = 10_000
+
+// validateBurnRebase checks the per-call limit of the burn rebase.
+func (c *TokenConfig) validateBurnRebase(errs *ValidationError) {
+	if c.BurnRebaseMaxBps <= 0 || c.BurnRebaseMaxBps >= bpsDenominator {
+		errs.add("BurnRebaseMaxBps", fmt.Sprintf("burn rebase limit %d bps must be between 1 and %d — a rebase cannot burn every balance", c.BurnRebaseMaxBps, bpsDenominator-1))
+	}
+}
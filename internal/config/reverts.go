@@ -0,0 +1,28 @@
+package config
+
+import "fmt"
+
+// revertMessages are the require() reasons used in place of each custom
+// error when RequireStrings is set.
+var revertMessages = map[string]string{
+	"EmissionScheduleExceeded": "amount exceeds emission schedule",
+	"BatchLengthMismatch":      "array length mismatch",
+	"UnauthorizedBridge":       "caller is not the bridge",
+	"AlreadyClaimed":           "already claimed",
+	"InvalidMerkleProof":       "invalid merkle proof",
+	"LockedBalanceExceeded":    "transfer exceeds unlocked balance",
+	"LockReleaseInPast":        "release time is in the past",
+	"TransferToTokenContract":  "transfer to the token contract",
+	"InvalidBurnRebase":        "invalid burn rebase",
+}
+
+// RevertReason returns the require() reason string standing in for the
+// custom error errName, prefixed with the contract name in the style of
+// OpenZeppelin v4 ("MyToken: array length mismatch").
+func (c *TokenConfig) RevertReason(errName string) (string, error) {
+	msg, ok := revertMessages[errName]
+	if !ok {
+		return "", fmt.Errorf("no revert reason for error %s", errName)
+	}
+	return c.SafeName() + ": " + msg, nil
+}
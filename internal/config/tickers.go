@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// knownTickers lists the symbols of widely held tokens. A new token reusing
+// one is usually a mistake, or looks like an impersonation to users.
+const knownTickers = `
+	USDC USDT DAI BUSD TUSD USDP FRAX PYUSD GUSD LUSD
+	ETH WETH STETH WSTETH RETH CBETH BTC WBTC CBBTC TBTC
+	BNB MATIC POL ARB OP AVAX SOL DOT ADA TRX TON XRP LTC
+	LINK UNI AAVE MKR SNX CRV COMP LDO SUSHI BAL YFI
+	SHIB PEPE DOGE FLOKI BONK APE GRT IMX ENS
+`
+
+// tickerWarning returns a warning if Symbol matches a known ticker, or "".
+func (c *TokenConfig) tickerWarning() string {
+	if !slices.Contains(strings.Fields(knownTickers), c.Symbol) {
+		return ""
+	}
+	return fmt.Sprintf("symbol %s is already used by a well-known token — confirm this is intentional, since wallets and users may confuse the two", c.Symbol)
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// wizardOption is a Wizard field that is either `false` or a string
+// variant (e.g. votes: "blocknumber", access: "roles"). `true` is kept as
+// "true" so callers can map it to the Wizard's default variant.
+type wizardOption string
+
+func (o *wizardOption) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		if b {
+			*o = "true"
+		} else {
+			*o = ""
+		}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("expected boolean or string, got %s", data)
+	}
+	*o = wizardOption(s)
+	return nil
+}
+
+// wizardSpec mirrors the ERC-20 options exported by the OpenZeppelin
+// Contracts Wizard (https://wizard.openzeppelin.com).
+type wizardSpec struct {
+	Name        string       `json:"name"`
+	Symbol      string       `json:"symbol"`
+	Premint     string       `json:"premint"`
+	Mintable    bool         `json:"mintable"`
+	Burnable    bool         `json:"burnable"`
+	Pausable    bool         `json:"pausable"`
+	Permit      bool         `json:"permit"`
+	Votes       wizardOption `json:"votes"`
+	FlashMint   bool         `json:"flashmint"`
+	Access      wizardOption `json:"access"`
+	Upgradeable wizardOption `json:"upgradeable"`
+	Info        struct {
+		License string `json:"license"`
+	} `json:"info"`
+}
+
+// FromWizard maps an OpenZeppelin Wizard ERC-20 JSON spec onto a
+// TokenConfig. Wizard options erc20gen cannot reproduce (flash minting,
+// managed access) are rejected rather than silently dropped.
+func FromWizard(data []byte) (*TokenConfig, error) {
+	var spec wizardSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid wizard JSON: %w", err)
+	}
+
+	cfg := &TokenConfig{
+		Name:            spec.Name,
+		Symbol:          spec.Symbol,
+		Decimals:        18, // the Wizard always uses ERC20's default
+		InitialSupply:   strings.TrimSpace(spec.Premint),
+		Mintable:        spec.Mintable,
+		Burnable:        spec.Burnable,
+		Pausable:        spec.Pausable,
+		Permit:          spec.Permit,
+		License:         LicenseType(spec.Info.License),
+		SolidityVersion: "^0.8.24",
+	}
+	if cfg.InitialSupply == "0" {
+		cfg.InitialSupply = ""
+	}
+
+	var errs []string
+	if spec.FlashMint {
+		errs = append(errs, "flashmint is not supported by erc20gen")
+	}
+
+	switch spec.Votes {
+	case "":
+		// disabled
+	case "true", "blocknumber":
+		cfg.Votes, cfg.ClockMode = true, ClockBlockNumber
+	case "timestamp":
+		cfg.Votes, cfg.ClockMode = true, ClockTimestamp
+	default:
+		errs = append(errs, fmt.Sprintf("unknown votes option %q", spec.Votes))
+	}
+
+	switch spec.Access {
+	case "":
+		cfg.AccessControl = AccessNone
+	case "true", "ownable":
+		cfg.AccessControl = AccessOwnable
+	case "roles":
+		cfg.AccessControl = AccessRoles
+	case "managed":
+		errs = append(errs, "managed access (AccessManager) is not supported by erc20gen")
+	default:
+		errs = append(errs, fmt.Sprintf("unknown access option %q", spec.Access))
+	}
+
+	switch spec.Upgradeable {
+	case "":
+		cfg.Upgradeable = UpgradeNone
+	case "true", "transparent":
+		cfg.Upgradeable = UpgradeTransparent
+	case "uups":
+		cfg.Upgradeable = UpgradeUUPS
+	default:
+		errs = append(errs, fmt.Sprintf("unknown upgradeable option %q", spec.Upgradeable))
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unsupported wizard spec:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return cfg, nil
+}
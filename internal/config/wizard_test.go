@@ -0,0 +1,68 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleWizardJSON = `{
+  "name": "GovToken",
+  "symbol": "GOV",
+  "burnable": true,
+  "pausable": true,
+  "premint": "1000000",
+  "mintable": true,
+  "permit": true,
+  "votes": "timestamp",
+  "flashmint": false,
+  "access": "roles",
+  "upgradeable": "uups",
+  "info": { "license": "MIT", "securityContact": "" }
+}`
+
+func TestFromWizard(t *testing.T) {
+	cfg, err := config.FromWizard([]byte(sampleWizardJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, "GovToken", cfg.Name)
+	assert.Equal(t, "GOV", cfg.Symbol)
+	assert.Equal(t, uint8(18), cfg.Decimals)
+	assert.Equal(t, "1000000", cfg.InitialSupply)
+	assert.True(t, cfg.Mintable)
+	assert.True(t, cfg.Burnable)
+	assert.True(t, cfg.Pausable)
+	assert.True(t, cfg.Permit)
+	assert.True(t, cfg.Votes)
+	assert.Equal(t, config.ClockTimestamp, cfg.ClockMode)
+	assert.Equal(t, config.AccessRoles, cfg.AccessControl)
+	assert.Equal(t, config.UpgradeUUPS, cfg.Upgradeable)
+	assert.Equal(t, config.LicenseMIT, cfg.License)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestFromWizard_BooleanVariants(t *testing.T) {
+	cfg, err := config.FromWizard([]byte(`{"name":"T","symbol":"T","premint":"0","votes":true,"access":false,"upgradeable":true}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, cfg.InitialSupply, "a zero premint means no initial mint")
+	assert.True(t, cfg.Votes)
+	assert.Equal(t, config.ClockBlockNumber, cfg.ClockMode)
+	assert.Equal(t, config.AccessNone, cfg.AccessControl)
+	assert.Equal(t, config.UpgradeTransparent, cfg.Upgradeable)
+}
+
+func TestFromWizard_Unsupported(t *testing.T) {
+	_, err := config.FromWizard([]byte(`{"name":"T","symbol":"T","flashmint":true,"access":"managed"}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "flashmint")
+	assert.Contains(t, err.Error(), "managed")
+}
+
+func TestFromWizard_InvalidJSON(t *testing.T) {
+	_, err := config.FromWizard([]byte(`{"votes": 3}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid wizard JSON")
+}
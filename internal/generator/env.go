@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// EnvVar is a variable the generated .env.example declares.
+type EnvVar struct {
+	Name    string
+	Comment string
+}
+
+// EnvVars lists the .env.example variables in file order. The names match
+// what the deploy scripts and README tell the user to set.
+var EnvVars = []EnvVar{
+	{"DEPLOYER_PRIVATE_KEY", "Private key of the deploying account — funds it controls are at risk if this leaks"},
+	{"RPC_URL", "JSON-RPC endpoint of the target network, read by hardhat.config.js"},
+	{"ETHERSCAN_API_KEY", "Block explorer API key used to verify the contract after deployment"},
+}
+
+// GenerateEnvExample renders a .env.example with an empty placeholder for
+// every EnvVars entry.
+func (g *Generator) GenerateEnvExample() (string, error) {
+	return g.GenerateEnvExampleCtx(context.Background())
+}
+
+// GenerateEnvExampleCtx is GenerateEnvExample, aborted once ctx is done.
+func (g *Generator) GenerateEnvExampleCtx(ctx context.Context) (string, error) {
+	return g.render(ctx, "env.example.tmpl", struct {
+		*config.TokenConfig
+		Vars []EnvVar
+	}{g.cfg, EnvVars})
+}
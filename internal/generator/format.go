@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// Format applies cfg's whitespace style (Indent, EOL) to the generated file
+// at filePath. Leading indentation is measured in the width the templates
+// use for that file type — 4 spaces in Solidity, 2 elsewhere, a tab counting
+// as one level — and re-expressed in cfg.Indent; spaces left over (such as
+// the one before "*" in a NatSpec block) are kept. Markdown and YAML
+// indentation is significant (YAML forbids tabs), so README and workflow
+// files only get their line endings changed.
+func Format(cfg *config.TokenConfig, filePath, src string) string {
+	if !Reformats(cfg) {
+		return src
+	}
+	width := 2
+	switch path.Ext(filePath) {
+	case ".sol":
+		width = 4
+	case ".md", ".yml":
+		width = 0
+	}
+
+	unit := ""
+	if width > 0 {
+		switch cfg.Indent {
+		case "":
+			unit = strings.Repeat(" ", width)
+		case "tabs":
+			unit = "\t"
+		default:
+			n, _ := strconv.Atoi(cfg.Indent) // checked by Validate
+			unit = strings.Repeat(" ", n)
+		}
+	}
+	eol := "\n"
+	if cfg.EOL == config.LineEndingCRLF {
+		eol = "\r\n"
+	}
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if unit != "" {
+			line = reindent(line, width, unit)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, eol)
+}
+
+// Reformats returns true if Format changes files under cfg; otherwise
+// rendered output can be written out as is.
+func Reformats(cfg *config.TokenConfig) bool {
+	return cfg.Indent != "" || cfg.EOL == config.LineEndingCRLF
+}
+
+// reindent rewrites line's leading whitespace, read as levels of width
+// columns, in levels of unit.
+func reindent(line string, width int, unit string) string {
+	cols, i := 0, 0
+	for ; i < len(line) && (line[i] == ' ' || line[i] == '\t'); i++ {
+		if line[i] == '\t' {
+			cols += width - cols%width
+		} else {
+			cols++
+		}
+	}
+	if i == len(line) {
+		return "" // whitespace-only line
+	}
+	return strings.Repeat(unit, cols/width) + strings.Repeat(" ", cols%width) + line[i:]
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	src := "contract Token {\n    /**\n     * @dev x\n     */\n\tfunction f() {\n\t    return;\n\t}\n    \n}\n"
+
+	tests := []struct {
+		name   string
+		indent string
+		eol    config.LineEnding
+		path   string
+		want   string
+	}{
+		{"unchanged by default", "", config.LineEndingLF, "contracts/Token.sol", src},
+		{"crlf keeps indentation", "", config.LineEndingCRLF, "contracts/Token.sol",
+			"contract Token {\r\n    /**\r\n     * @dev x\r\n     */\r\n    function f() {\r\n        return;\r\n    }\r\n\r\n}\r\n"},
+		{"tabs to two spaces", "2", config.LineEndingLF, "contracts/Token.sol",
+			"contract Token {\n  /**\n   * @dev x\n   */\n  function f() {\n    return;\n  }\n\n}\n"},
+		{"spaces to tabs", "tabs", config.LineEndingLF, "contracts/Token.sol",
+			"contract Token {\n\t/**\n\t * @dev x\n\t */\n\tfunction f() {\n\t\treturn;\n\t}\n\n}\n"},
+		{"js levels are two columns", "4", config.LineEndingLF, "scripts/deploy.js",
+			"contract Token {\n        /**\n         * @dev x\n         */\n    function f() {\n            return;\n    }\n\n}\n"},
+		{"markdown keeps indentation", "tabs", config.LineEndingCRLF, "README.md",
+			"contract Token {\r\n    /**\r\n     * @dev x\r\n     */\r\n\tfunction f() {\r\n\t    return;\r\n\t}\r\n    \r\n}\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.TokenConfig{Indent: tt.indent, EOL: tt.eol}
+			assert.Equal(t, tt.want, Format(cfg, tt.path, src))
+		})
+	}
+}
+
+func TestFormat_CRLFIsIdempotent(t *testing.T) {
+	cfg := &config.TokenConfig{EOL: config.LineEndingCRLF}
+	once := Format(cfg, "contracts/Token.sol", "a\nb\r\n")
+	assert.Equal(t, "a\r\nb\r\n", once)
+	assert.Equal(t, once, Format(cfg, "contracts/Token.sol", once))
+}
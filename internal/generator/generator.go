@@ -2,71 +2,411 @@ package generator
 
 import (
 	"bytes"
+	"context"
 	"embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
 	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/Zubimendi/erc20gen/internal/config"
 )
 
+// Version is the erc20gen release stamped into generated files.
+const Version = "1.0.0"
+
 //go:embed templates/*
 var templatesFS embed.FS
 
 // Generator holds config and renders templates.
 type Generator struct {
 	cfg *config.TokenConfig
+	rng *rand.Rand
+	log *slog.Logger // nil = silent
 }
 
-// New creates a new Generator.
+// New creates a new Generator with a time-based seed.
 func New(cfg *config.TokenConfig) *Generator {
-	return &Generator{cfg: cfg}
+	return NewWithSeed(cfg, time.Now().UnixNano())
+}
+
+// NewWithSeed creates a new Generator whose placeholder values (e.g. sample
+// holder addresses) are drawn from the given seed. With a fixed seed the
+// full output is byte-identical across runs.
+func NewWithSeed(cfg *config.TokenConfig, seed int64) *Generator {
+	return &Generator{cfg: cfg, rng: rand.New(rand.NewSource(seed))} // #nosec G404 -- placeholders only, not security-sensitive
+}
+
+// WithLogger makes g log each template it renders at debug level to l
+// (nil = silent) and returns g.
+func (g *Generator) WithLogger(l *slog.Logger) *Generator {
+	g.log = l
+	return g
+}
+
+// contractStyles maps each non-standard cfg.Style to the template variant
+// layered over contract.sol.tmpl; its {{define}}s replace the base's blocks.
+var contractStyles = map[config.ContractStyle]string{
+	config.StyleMinimal: "contract.minimal.sol.tmpl",
+	config.StyleVerbose: "contract.verbose.sol.tmpl",
 }
 
-// GenerateContract renders the Solidity ERC-20 contract.
+// GenerateContract renders the Solidity ERC-20 contract in cfg.Style,
+// minified when cfg.Minify is set or the style is minimal.
 func (g *Generator) GenerateContract() (string, error) {
-	tmpl, err := template.New("contract.sol.tmpl").Funcs(templateFuncs()).ParseFS(templatesFS, "templates/contract.sol.tmpl")
-	if err != nil {
-		return "", err
+	return g.GenerateContractCtx(context.Background())
+}
+
+// GenerateContractCtx is GenerateContract, aborted once ctx is done.
+func (g *Generator) GenerateContractCtx(ctx context.Context) (string, error) {
+	return collect(func(w io.Writer) error { return g.GenerateContractToCtx(ctx, w) })
+}
+
+// GenerateContractTo is GenerateContract, executing the template straight
+// into w. A minified contract is still buffered, since minify needs the
+// whole source.
+func (g *Generator) GenerateContractTo(w io.Writer) error {
+	return g.GenerateContractToCtx(context.Background(), w)
+}
+
+// GenerateContractToCtx is GenerateContractTo, aborted once ctx is done.
+// w may hold a partial contract when it returns an error.
+func (g *Generator) GenerateContractToCtx(ctx context.Context, w io.Writer) error {
+	var variants []string
+	if v, ok := contractStyles[g.cfg.Style]; ok {
+		variants = append(variants, v)
 	}
-	var buf bytes.Buffer
-	if err := tmpl.ExecuteTemplate(&buf, "contract.sol.tmpl", g.cfg); err != nil {
-		return "", err
+	if !g.cfg.Minifies() {
+		return g.renderTo(ctx, w, "contract.sol.tmpl", g.cfg, variants...)
 	}
-	return buf.String(), nil
+	src, err := g.render(ctx, "contract.sol.tmpl", g.cfg, variants...)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, minify(src))
+	return err
 }
 
-// GenerateDeployScript renders a Hardhat deploy script (JS).
+// GenerateDeployScript renders a Hardhat deploy script (JS): a `hardhat run`
+// script by default, or a hardhat-deploy module when cfg.DeployStyle is
+// hardhat-deploy.
 func (g *Generator) GenerateDeployScript() (string, error) {
-	tmpl, err := template.New("deploy.js.tmpl").Funcs(templateFuncs()).ParseFS(templatesFS, "templates/deploy.js.tmpl")
+	return g.GenerateDeployScriptCtx(context.Background())
+}
+
+// GenerateDeployScriptCtx is GenerateDeployScript, aborted once ctx is done.
+func (g *Generator) GenerateDeployScriptCtx(ctx context.Context) (string, error) {
+	return collect(func(w io.Writer) error { return g.GenerateDeployScriptToCtx(ctx, w) })
+}
+
+// GenerateDeployScriptTo is GenerateDeployScript, executing the template
+// straight into w.
+func (g *Generator) GenerateDeployScriptTo(w io.Writer) error {
+	return g.GenerateDeployScriptToCtx(context.Background(), w)
+}
+
+// GenerateDeployScriptToCtx is GenerateDeployScriptTo, aborted once ctx is
+// done.
+func (g *Generator) GenerateDeployScriptToCtx(ctx context.Context, w io.Writer) error {
+	if g.cfg.UsesHardhatDeploy() {
+		return g.renderTo(ctx, w, "deploy.hardhat-deploy.js.tmpl", g.cfg)
+	}
+	return g.renderTo(ctx, w, "deploy.js.tmpl", g.cfg)
+}
+
+// GenerateGovernor renders the companion OpenZeppelin Governor for a Votes
+// token (cfg.WithGovernor).
+func (g *Generator) GenerateGovernor() (string, error) {
+	return g.GenerateGovernorCtx(context.Background())
+}
+
+// GenerateGovernorCtx is GenerateGovernor, aborted once ctx is done.
+func (g *Generator) GenerateGovernorCtx(ctx context.Context) (string, error) {
+	return g.render(ctx, "governor.sol.tmpl", g.cfg)
+}
+
+// GenerateTestSkeleton renders a Hardhat test skeleton: ethers (JS) by
+// default, or viem (TypeScript) when cfg.TestStyle is viem-ts.
+func (g *Generator) GenerateTestSkeleton() (string, error) {
+	return g.GenerateTestSkeletonCtx(context.Background())
+}
+
+// GenerateTestSkeletonCtx is GenerateTestSkeleton, aborted once ctx is done.
+func (g *Generator) GenerateTestSkeletonCtx(ctx context.Context) (string, error) {
+	return collect(func(w io.Writer) error { return g.GenerateTestSkeletonToCtx(ctx, w) })
+}
+
+// GenerateTestSkeletonTo is GenerateTestSkeleton, executing the template
+// straight into w.
+func (g *Generator) GenerateTestSkeletonTo(w io.Writer) error {
+	return g.GenerateTestSkeletonToCtx(context.Background(), w)
+}
+
+// GenerateTestSkeletonToCtx is GenerateTestSkeletonTo, aborted once ctx is
+// done.
+func (g *Generator) GenerateTestSkeletonToCtx(ctx context.Context, w io.Writer) error {
+	if g.cfg.TestStyle == config.TestStyleViemTS {
+		return g.renderTo(ctx, w, "test.viem.ts.tmpl", g.cfg)
+	}
+	return g.renderTo(ctx, w, "test.js.tmpl", g.cfg)
+}
+
+// GenerateSingleFile renders the contract followed by a commented-out
+// deployment snippet, so the whole output can be pasted into Remix as one file.
+func (g *Generator) GenerateSingleFile() (string, error) {
+	return g.GenerateSingleFileCtx(context.Background())
+}
+
+// GenerateSingleFileCtx is GenerateSingleFile, aborted once ctx is done.
+func (g *Generator) GenerateSingleFileCtx(ctx context.Context) (string, error) {
+	contract, err := g.GenerateContractCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+	deploy, err := g.GenerateDeployScriptCtx(ctx)
 	if err != nil {
 		return "", err
 	}
+	return g.render(ctx, "single.sol.tmpl", struct {
+		*config.TokenConfig
+		Contract string
+		Deploy   string
+	}{g.cfg, contract, deploy})
+}
+
+// ProjectFiles describes the generated project for the README and CI
+// workflow: the --layout name and the root-relative, slash-separated path of
+// each file. Governor, Deploy, Test, Invariants, ABI, CI, Env, Typechain,
+// Compiler and Foundry are empty when those files were not generated.
+type ProjectFiles struct {
+	Layout     string
+	Contract   string
+	Governor   string
+	Deploy     string
+	Test       string
+	Invariants string
+	ABI        string
+	CI         string
+	Env        string
+	Typechain  string
+	Compiler   string
+	Foundry    string
+}
+
+// GenerateCIWorkflow renders a GitHub Actions workflow that installs the
+// toolchain for files.Layout and runs compile and test on every push.
+func (g *Generator) GenerateCIWorkflow(files ProjectFiles) (string, error) {
+	return g.GenerateCIWorkflowCtx(context.Background(), files)
+}
+
+// GenerateCIWorkflowCtx is GenerateCIWorkflow, aborted once ctx is done.
+func (g *Generator) GenerateCIWorkflowCtx(ctx context.Context, files ProjectFiles) (string, error) {
+	return g.render(ctx, "ci.yml.tmpl", struct {
+		*config.TokenConfig
+		Files ProjectFiles
+	}{g.cfg, files})
+}
+
+// TypeChain output directory and target written to typechain.config.js;
+// these are also the @typechain/hardhat defaults.
+const (
+	typechainOutDir = "typechain-types"
+	typechainTarget = "ethers-v6"
+)
+
+// GenerateTypechainConfig renders a hardhat.config.js fragment that enables
+// TypeChain bindings for the ethers v6 target.
+func (g *Generator) GenerateTypechainConfig() (string, error) {
+	return g.GenerateTypechainConfigCtx(context.Background())
+}
+
+// GenerateTypechainConfigCtx is GenerateTypechainConfig, aborted once ctx
+// is done.
+func (g *Generator) GenerateTypechainConfigCtx(ctx context.Context) (string, error) {
+	return g.render(ctx, "typechain.config.js.tmpl", struct {
+		*config.TokenConfig
+		OutDir, Target string
+	}{g.cfg, typechainOutDir, typechainTarget})
+}
+
+// GenerateCompilerConfig renders a hardhat.config.js fragment pinning the
+// solc release and EVMVersion the contract is compiled for.
+func (g *Generator) GenerateCompilerConfig() (string, error) {
+	return g.GenerateCompilerConfigCtx(context.Background())
+}
+
+// GenerateCompilerConfigCtx is GenerateCompilerConfig, aborted once ctx is
+// done.
+func (g *Generator) GenerateCompilerConfigCtx(ctx context.Context) (string, error) {
+	return g.render(ctx, "compiler.config.js.tmpl", g.cfg)
+}
+
+// GenerateFoundryConfig renders a foundry.toml for the foundry layout with
+// the same solc release and EVMVersion as GenerateCompilerConfig.
+func (g *Generator) GenerateFoundryConfig() (string, error) {
+	return g.GenerateFoundryConfigCtx(context.Background())
+}
+
+// GenerateFoundryConfigCtx is GenerateFoundryConfig, aborted once ctx is
+// done.
+func (g *Generator) GenerateFoundryConfigCtx(ctx context.Context) (string, error) {
+	return g.render(ctx, "foundry.toml.tmpl", g.cfg)
+}
+
+// GenerateReadme renders a project README.md describing the token and the
+// compile, test and deploy commands for the generated files.
+func (g *Generator) GenerateReadme(files ProjectFiles) (string, error) {
+	return g.GenerateReadmeCtx(context.Background(), files)
+}
+
+// GenerateReadmeCtx is GenerateReadme, aborted once ctx is done.
+func (g *Generator) GenerateReadmeCtx(ctx context.Context, files ProjectFiles) (string, error) {
+	return g.render(ctx, "readme.md.tmpl", struct {
+		*config.TokenConfig
+		Files ProjectFiles
+	}{g.cfg, files})
+}
+
+// render is renderTo into a string.
+func (g *Generator) render(ctx context.Context, name string, data interface{}, variants ...string) (string, error) {
+	return collect(func(w io.Writer) error { return g.renderTo(ctx, w, name, data, variants...) })
+}
+
+// collect returns what write wrote, or "" if it failed.
+func collect(write func(io.Writer) error) (string, error) {
 	var buf bytes.Buffer
-	if err := tmpl.ExecuteTemplate(&buf, "deploy.js.tmpl", g.cfg); err != nil {
+	if err := write(&buf); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
 }
 
-// GenerateTestSkeleton renders a Hardhat test skeleton (JS).
-func (g *Generator) GenerateTestSkeleton() (string, error) {
-	tmpl, err := template.New("test.js.tmpl").Funcs(templateFuncs()).ParseFS(templatesFS, "templates/test.js.tmpl")
+// renderTo executes the named template into w. Variants are parsed after
+// it, so their {{define}}s override its {{block}}s. Output goes through a
+// ctxWriter, so a done ctx aborts execution at the template's next write.
+func (g *Generator) renderTo(ctx context.Context, w io.Writer, name string, data interface{}, variants ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if g.log != nil {
+		g.log.DebugContext(ctx, "rendering template", "template", name)
+	}
+	patterns := []string{"templates/" + name, "templates/partials/*.tmpl"}
+	for _, v := range variants {
+		patterns = append(patterns, "templates/"+v)
+	}
+	tmpl, err := template.New(name).Funcs(g.templateFuncs()).ParseFS(templatesFS, patterns...)
 	if err != nil {
-		return "", err
+		return err
 	}
-	var buf bytes.Buffer
-	if err := tmpl.ExecuteTemplate(&buf, "test.js.tmpl", g.cfg); err != nil {
-		return "", err
+	return tmpl.ExecuteTemplate(ctxWriter{ctx, w}, name, data)
+}
+
+// ctxWriter fails every write once ctx is done; text/template stops
+// executing on the first write error and returns it.
+type ctxWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w ctxWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
 	}
-	return buf.String(), nil
+	return w.w.Write(p)
 }
 
-func templateFuncs() template.FuncMap {
+func (g *Generator) templateFuncs() template.FuncMap {
 	return template.FuncMap{
-		"join":  strings.Join,
-		"upper": strings.ToUpper,
-		"lower": strings.ToLower,
-		"quote": func(s string) string { return "\"" + s + "\"" },
-		"add":   func(a, b int) int { return a + b },
+		"join":          strings.Join,
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"quote":         func(s string) string { return "\"" + s + "\"" },
+		"solString":     solString,
+		"add":           func(a, b int) int { return a + b },
+		"sampleAddress": g.sampleAddress,
+		"comment":       comment,
+		"explain":       explain,
+		"version":       func() string { return Version },
+		"configHash":    g.cfg.ConfigHash,
+		"dict":          dict,
+		"sepNum":        sepNum,
+		"checksum":      config.ChecksumAddress,
+	}
+}
+
+// solString renders s as a Solidity string literal. Non-ASCII text needs
+// the unicode prefix, which Solidity requires since 0.7; Validate keeps
+// quotes and backslashes out of the strings passed here.
+func solString(s string) string {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return `unicode"` + s + `"`
+		}
+	}
+	return `"` + s + `"`
+}
+
+// sepNum groups the digits of an integer literal in threes with Solidity's
+// underscore separator (1000000 → 1_000_000). Anything that is not a plain
+// run of digits is returned unchanged.
+func sepNum(s string) string {
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return s
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
 	}
-}
\ No newline at end of file
+	return b.String()
+}
+
+// comment prefixes every line of s with "// ".
+func comment(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("// "+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dict builds a map from alternating key/value arguments so partials can
+// take named parameters.
+func dict(kv ...interface{}) (map[string]interface{}, error) {
+	if len(kv)%2 != 0 {
+		return nil, fmt.Errorf("dict: odd number of arguments")
+	}
+	m := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", kv[i])
+		}
+		m[k] = kv[i+1]
+	}
+	return m, nil
+}
+
+// explain returns the --explain comment for an inherited contract.
+// Contracts erc20gen does not know came from --extra-parent.
+func explain(contract string) string {
+	if s := config.ExplainContract(contract); s != "" {
+		return "// " + s
+	}
+	return "// " + contract + ": extra parent (--extra-parent) — its overrides are not generated"
+}
+
+// sampleAddress returns a pseudo-random 20-byte hex address for use as a
+// placeholder in generated scripts and tests.
+func (g *Generator) sampleAddress() string {
+	b := make([]byte, 20)
+	_, _ = g.rng.Read(b)
+	return fmt.Sprintf("0x%x", b)
+}
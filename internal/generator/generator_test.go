@@ -1,13 +1,20 @@
 package generator_test
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 	"github.com/Zubimendi/erc20gen/internal/config"
 	"github.com/Zubimendi/erc20gen/internal/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // ─── Helper ──────────────────────────────────────────────────────────────────
@@ -44,7 +51,6 @@ func TestTokenConfig_Validate_InvalidSymbol(t *testing.T) {
 		name   string
 		symbol string
 	}{
-		{"lowercase", "mtk"},
 		{"too long", "TOOLONGSYMBOL"},
 		{"special chars", "MT K"},
 		{"empty", ""},
@@ -59,6 +65,28 @@ func TestTokenConfig_Validate_InvalidSymbol(t *testing.T) {
 	}
 }
 
+func TestTokenConfig_Validate_SymbolCase(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Symbol = "mtk"
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "MTK", cfg.Symbol)
+	assert.Equal(t, config.SymbolCaseAutoUpper, cfg.SymbolCase)
+
+	cfg = baseConfig()
+	cfg.Symbol = "mtk"
+	cfg.SymbolCase = config.SymbolCaseStrict
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--symbol-case auto-upper uppercases it for you")
+	assert.Equal(t, "mtk", cfg.Symbol)
+
+	cfg = baseConfig()
+	cfg.SymbolCase = "lower"
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid symbol case "lower"`)
+}
+
 func TestTokenConfig_Validate_ValidSymbols(t *testing.T) {
 	valid := []string{"MTK", "USDC", "BTC", "A", "TOKEN123", "T1"}
 	for _, s := range valid {
@@ -70,10 +98,114 @@ func TestTokenConfig_Validate_ValidSymbols(t *testing.T) {
 
 func TestTokenConfig_Validate_DecimalsOutOfRange(t *testing.T) {
 	cfg := baseConfig()
-	cfg.Decimals = 19
+	cfg.Decimals = 78
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decimals must be between 0 and 77")
+}
+
+func TestTokenConfig_Warnings_HighDecimals(t *testing.T) {
+	tests := []struct {
+		decimals uint8
+		warns    bool
+	}{
+		{18, false},
+		{24, true},
+		{77, true},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(int(tt.decimals)), func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Decimals = tt.decimals
+			cfg.InitialSupply = "1"
+			require.NoError(t, cfg.Validate())
+			if tt.warns {
+				require.Len(t, cfg.Warnings(), 1)
+				assert.Contains(t, cfg.Warnings()[0], "exceeds the standard 18")
+			} else {
+				assert.Empty(t, cfg.Warnings())
+			}
+		})
+	}
+}
+
+func TestTokenConfig_Warnings_NameMatchesSymbol(t *testing.T) {
+	tests := []struct {
+		name, display string
+		warns         bool
+	}{
+		{"TST", "", true},
+		{"tst", "", true},
+		{"Tst", "Test Token", false},
+		{"TestToken", "tst", true},
+		{"TestToken", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.display, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Name = tt.name
+			cfg.DisplayName = tt.display
+			require.NoError(t, cfg.Validate())
+			if tt.warns {
+				require.Len(t, cfg.Warnings(), 1)
+				assert.Contains(t, cfg.Warnings()[0], "matches symbol TST")
+			} else {
+				assert.Empty(t, cfg.Warnings())
+			}
+		})
+	}
+}
+
+func TestTokenConfig_Warnings_KnownTicker(t *testing.T) {
+	tests := []struct {
+		symbol string
+		warns  bool
+	}{
+		{"USDC", true},
+		{"QZXV7", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Symbol = tt.symbol
+			cfg.CheckTicker = true
+			require.NoError(t, cfg.Validate())
+			if tt.warns {
+				require.Len(t, cfg.Warnings(), 1)
+				assert.Contains(t, cfg.Warnings()[0], "symbol USDC is already used by a well-known token")
+			} else {
+				assert.Empty(t, cfg.Warnings())
+			}
+		})
+	}
+}
+
+func TestTokenConfig_Warnings_KnownTickerIsOptIn(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Symbol = "USDC"
+	require.NoError(t, cfg.Validate())
+	assert.Empty(t, cfg.Warnings())
+}
+
+func TestTokenConfig_Validate_ScaledSupplyOverflow(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Decimals = 77
+	cfg.InitialSupply = "12" // 12 * 10**77 > 2**256
 	err := cfg.Validate()
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "decimals must be between 0 and 18")
+	assert.Contains(t, err.Error(), "overflows uint256")
+}
+
+func TestGenerator_GenerateContract_HighDecimalsCap(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Decimals = 24
+	cfg.MaxSupply = "5000000"
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "return 24;")
+	assert.Contains(t, contract, "ERC20Capped(5_000_000_000_000_000_000_000_000_000_000)")
 }
 
 func TestTokenConfig_Validate_InitialSupplyExceedsCap(t *testing.T) {
@@ -95,10 +227,34 @@ func TestTokenConfig_Validate_InvalidAccessControl(t *testing.T) {
 
 func TestTokenConfig_Validate_VotesAutoEnablesSnapshot(t *testing.T) {
 	cfg := baseConfig()
+	cfg.OZVersion = config.OZv4
 	cfg.Votes = true
 	cfg.Snapshot = false
 	require.NoError(t, cfg.Validate())
-	assert.True(t, cfg.Snapshot, "Votes should auto-enable Snapshot")
+	assert.True(t, cfg.Snapshot, "Votes should auto-enable Snapshot on OZ v4")
+}
+
+func TestTokenConfig_Validate_VotesRequiresPermitOnV5(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Votes = true
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, config.OZv5, cfg.OZVersion, "v5 is the default")
+	assert.False(t, cfg.Snapshot, "Votes must not auto-enable Snapshot on OZ v5")
+	assert.True(t, cfg.Permit, "Votes should pull in ERC20Permit on OZ v5")
+
+	paths := cfg.ImportPaths()
+	assert.Contains(t, paths, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Permit.sol")
+	assert.NotContains(t, paths, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Snapshot.sol")
+	assert.Contains(t, cfg.InheritanceList(), "ERC20Permit")
+	assert.NotContains(t, cfg.InheritanceList(), "ERC20Snapshot")
+}
+
+func TestTokenConfig_Validate_InvalidOZVersion(t *testing.T) {
+	cfg := baseConfig()
+	cfg.OZVersion = "6"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid OpenZeppelin version")
 }
 
 func TestTokenConfig_Validate_EmptyAccessControlDefaultsToOwnable(t *testing.T) {
@@ -108,6 +264,147 @@ func TestTokenConfig_Validate_EmptyAccessControlDefaultsToOwnable(t *testing.T)
 	assert.Equal(t, config.AccessOwnable, cfg.AccessControl)
 }
 
+func TestTokenConfig_Validate_InvalidUpgradeable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Upgradeable = "beacon"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid upgradeable type")
+}
+
+func TestTokenConfig_Validate_UpgradeableRejectsCap(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.MaxSupply = "10000000"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "capped supply is not supported for upgradeable tokens")
+}
+
+func TestTokenConfig_Validate_UUPSRequiresAccessControl(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.AccessControl = config.AccessNone
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "_authorizeUpgrade")
+}
+
+func TestTokenConfig_Validate_AdminFeaturesRequireAccessControl(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		field string
+		want  string
+	}{
+		{"mintable", func(c *config.TokenConfig) { c.Mintable = true }, "Mintable", "unguarded mint()"},
+		{"pausable", func(c *config.TokenConfig) { c.Pausable = true }, "Pausable", "unguarded pause()"},
+		{"snapshot", func(c *config.TokenConfig) { c.Snapshot = true }, "Snapshot", "unguarded snapshot()"},
+		{"admin burn", func(c *config.TokenConfig) { c.AdminBurn = true }, "AdminBurn", "unguarded burnFrom"},
+		{"locks", func(c *config.TokenConfig) { c.Locks = true }, "Locks", "unguarded lock()"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = config.AccessNone
+			tt.setup(cfg)
+
+			err := cfg.Validate()
+			var verr *config.ValidationError
+			require.ErrorAs(t, err, &verr)
+			require.Len(t, verr.Fields, 1)
+			assert.Equal(t, tt.field, verr.Fields[0].Field)
+			assert.Contains(t, verr.Fields[0].Message, tt.want)
+
+			cfg.AccessControl = config.AccessOwnable
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+}
+
+func TestTokenConfig_Validate_NormalizesLicenseCase(t *testing.T) {
+	tests := []struct {
+		license config.LicenseType
+		want    config.LicenseType
+	}{
+		{"mit", config.LicenseMIT},
+		{"Mit", config.LicenseMIT},
+		{"apache-2.0", config.LicenseApache20},
+		{" bsd-3-clause ", config.LicenseBSD3Clause},
+		{"unlicensed", config.LicenseUnlicensed},
+		{"unlicense", config.LicenseUnlicense},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.license), func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.License = tt.license
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.want, cfg.License)
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(contract, "// SPDX-License-Identifier: "+tt.want.String()+"\n"))
+		})
+	}
+
+	cfg := baseConfig()
+	cfg.License = "I\u017FC" // long s folds to "s" under Unicode rules, so strings.EqualFold would accept "ISC"
+	require.Error(t, cfg.Validate())
+}
+
+func TestTokenConfig_Validate_License(t *testing.T) {
+	tests := []struct {
+		name    string
+		license config.LicenseType
+		wantErr string
+	}{
+		{"MIT", "MIT", ""},
+		{"Apache", "Apache-2.0", ""},
+		{"GPL", "GPL-3.0", ""},
+		{"BSD", "BSD-3-Clause", ""},
+		{"unlicensed", "UNLICENSED", ""},
+		{"empty defaults to MIT", "", ""},
+		{"missing dash", "Apache2.0", `did you mean "Apache-2.0"?`},
+		{"unknown", "ProprietaryCorpLicense", "see https://spdx.org/licenses/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.License = tt.license
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "unknown SPDX license")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLicenseType(t *testing.T) {
+	assert.Equal(t, "Apache-2.0", config.LicenseApache20.String())
+	assert.Equal(t, "UNLICENSED", fmt.Sprint(config.LicenseUnlicensed))
+	assert.Len(t, config.LicenseType("").Values(), len(config.KnownLicenses))
+	for _, l := range config.KnownLicenses {
+		cfg := baseConfig()
+		cfg.License = l
+		require.NoError(t, cfg.Validate(), l)
+		assert.Equal(t, l, cfg.License, "a known license is already canonical")
+	}
+
+	// Arbitrary values convert to LicenseType but Validate rejects them.
+	for _, l := range []config.LicenseType{"MIT2", "GPL", "Proprietary"} {
+		cfg := baseConfig()
+		cfg.License = l
+		err := cfg.Validate()
+		var verr *config.ValidationError
+		require.ErrorAs(t, err, &verr, l)
+		assert.Contains(t, err.Error(), "unknown SPDX license")
+	}
+}
+
 // ─── ContractFileName Tests ───────────────────────────────────────────────────
 
 func TestTokenConfig_ContractFileName(t *testing.T) {
@@ -157,6 +454,52 @@ func TestTokenConfig_ImportPaths_AllFeatures(t *testing.T) {
 	assert.Contains(t, paths, "@openzeppelin/contracts/token/ERC20/extensions/ERC20Capped.sol")
 }
 
+func TestTokenConfig_ImportGroups(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessRoles
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.Pausable = true
+	cfg.Permit = true
+	cfg.ExtraImports = []string{"./extensions/Taxed.sol"}
+	require.NoError(t, cfg.Validate())
+
+	const up = "@openzeppelin/contracts-upgradeable/"
+	assert.Equal(t, []config.ImportGroup{
+		{Name: "Core", Paths: []string{up + "token/ERC20/ERC20Upgradeable.sol"}},
+		{Name: "Extensions", Paths: []string{up + "token/ERC20/extensions/ERC20PausableUpgradeable.sol", up + "token/ERC20/extensions/ERC20PermitUpgradeable.sol"}},
+		{Name: "Access control", Paths: []string{up + "access/AccessControlUpgradeable.sol"}},
+		{Name: "Upgradeability", Paths: []string{up + "proxy/utils/Initializable.sol", up + "proxy/utils/UUPSUpgradeable.sol"}},
+		{Name: "Utilities", Paths: []string{up + "utils/PausableUpgradeable.sol"}},
+		{Name: "Additional imports", Paths: []string{"./extensions/Taxed.sol"}},
+	}, cfg.ImportGroups())
+}
+
+func TestGenerator_GenerateContract_GroupImports(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Burnable = true
+	cfg.GroupImports = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `
+// Core
+import "@openzeppelin/contracts/token/ERC20/ERC20.sol";
+
+// Extensions
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol";
+
+// Access control
+import "@openzeppelin/contracts/access/Ownable.sol";
+`)
+	assert.NotContains(t, contract, "// Utilities")
+
+	cfg.GroupImports = false
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "// Core")
+}
+
 // ─── Generator Tests ──────────────────────────────────────────────────────────
 
 func TestGenerator_GenerateContract_ContainsRequiredElements(t *testing.T) {
@@ -174,7 +517,7 @@ func TestGenerator_GenerateContract_ContainsRequiredElements(t *testing.T) {
 	assert.Contains(t, contract, "contract TestToken is ERC20")
 	assert.Contains(t, contract, "Ownable")
 	assert.Contains(t, contract, "_mint(")
-	assert.Contains(t, contract, "1000000")
+	assert.Contains(t, contract, "1_000_000")
 }
 
 func TestGenerator_GenerateContract_MintableIncludesMintFunction(t *testing.T) {
@@ -218,155 +561,2517 @@ func TestGenerator_GenerateContract_PausableIncludesPauseFunctions(t *testing.T)
 	assert.Contains(t, contract, "_unpause()")
 }
 
-func TestGenerator_GenerateContract_RolesAccessControl(t *testing.T) {
-	cfg := baseConfig()
-	cfg.AccessControl = config.AccessRoles
-	cfg.Mintable = true
-	require.NoError(t, cfg.Validate())
+func TestGenerator_GenerateContract_AdminBurn(t *testing.T) {
+	tests := []struct {
+		name     string
+		access   config.AccessControlType
+		modifier string
+	}{
+		{"ownable", config.AccessOwnable, "function burnFrom(address from, uint256 amount) external onlyOwner"},
+		{"roles", config.AccessRoles, "function burnFrom(address from, uint256 amount) external onlyRole(BURNER_ROLE)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = tt.access
+			cfg.AdminBurn = true
+			require.NoError(t, cfg.Validate())
 
-	gen := generator.New(cfg)
-	contract, err := gen.GenerateContract()
-	require.NoError(t, err)
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
 
-	assert.Contains(t, contract, "MINTER_ROLE")
-	assert.Contains(t, contract, "onlyRole(MINTER_ROLE)")
-	assert.Contains(t, contract, "AccessControl")
-	assert.NotContains(t, contract, "onlyOwner")
+			assert.Contains(t, contract, tt.modifier)
+			assert.Contains(t, contract, "_burn(from, amount);")
+			assert.NotContains(t, contract, "ERC20Burnable", "admin burn must not pull in ERC20Burnable")
+		})
+	}
 }
 
-func TestGenerator_GenerateContract_NoAccessControl(t *testing.T) {
+func TestGenerator_GenerateContract_Locks(t *testing.T) {
+	tests := []struct {
+		name     string
+		access   config.AccessControlType
+		modifier string
+	}{
+		{"ownable", config.AccessOwnable, "function lock(address account, uint256 amount, uint64 releaseTime) external onlyOwner"},
+		{"roles", config.AccessRoles, "function lock(address account, uint256 amount, uint64 releaseTime) external onlyRole(LOCKER_ROLE)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = tt.access
+			cfg.Locks = true
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+
+			assert.Contains(t, contract, "struct Lock {\n        uint256 amount;\n        uint64 releaseTime;\n    }")
+			assert.Contains(t, contract, "mapping(address => Lock) public locks;")
+			assert.Contains(t, contract, tt.modifier)
+			assert.Contains(t, contract, "locks[account] = Lock(amount, releaseTime);")
+			assert.Contains(t, contract, "function lockedBalanceOf(address account) public view returns (uint256)")
+
+			// Locks alone still need the token's own _update hook.
+			assert.Contains(t, contract, "override(ERC20)")
+			assert.Contains(t, contract, "uint256 locked = lockedBalanceOf(from);")
+			assert.Contains(t, contract, "revert LockedBalanceExceeded(from, available, value);")
+			assert.Less(t, strings.Index(contract, "revert LockedBalanceExceeded"), strings.Index(contract, "super._update(from, to, value);"),
+				"the lock check must run before the balance moves")
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_LocksWithPausableSharesUpdate(t *testing.T) {
 	cfg := baseConfig()
-	cfg.AccessControl = config.AccessNone
-	cfg.Mintable = true
+	cfg.Locks = true
+	cfg.Pausable = true
 	require.NoError(t, cfg.Validate())
 
-	gen := generator.New(cfg)
-	contract, err := gen.GenerateContract()
+	contract, err := generator.New(cfg).GenerateContract()
 	require.NoError(t, err)
-
-	assert.NotContains(t, contract, "onlyOwner")
-	assert.NotContains(t, contract, "onlyRole")
-	assert.NotContains(t, contract, "Ownable")
+	assert.Equal(t, 1, strings.Count(contract, "function _update("))
+	assert.Contains(t, contract, "override(ERC20, ERC20Pausable)")
+	assert.Contains(t, contract, "revert LockedBalanceExceeded(from, available, value);")
 }
 
-func TestGenerator_GenerateContract_CustomDecimals(t *testing.T) {
+func TestGenerator_GenerateContract_RejectSelfTransfer(t *testing.T) {
 	cfg := baseConfig()
-	cfg.Decimals = 6
 	require.NoError(t, cfg.Validate())
+	plain, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "address(this)")
+	assert.NotContains(t, plain, "function _update(")
 
+	cfg.RejectSelfTransfer = true
+	cfg.Pausable = true
+	require.NoError(t, cfg.Validate())
 	gen := generator.New(cfg)
 	contract, err := gen.GenerateContract()
 	require.NoError(t, err)
+	assert.Contains(t, contract, "error TransferToTokenContract();")
+	assert.Contains(t, contract, "        if (to == address(this)) {\n            revert TransferToTokenContract();\n        }\n        super._update(from, to, value);")
+	assert.Equal(t, 1, strings.Count(contract, "function _update("))
 
-	assert.Contains(t, contract, "function decimals()")
-	assert.Contains(t, contract, "return 6;")
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, `.to.be.revertedWithCustomError(token, "TransferToTokenContract");`)
+
+	cfg.RequireStrings = true
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "error TransferToTokenContract")
+	assert.Contains(t, contract, `require(to != address(this), "TestToken: transfer to the token contract");`)
 }
 
-func TestGenerator_GenerateContract_Standard18DecimalsNoOverride(t *testing.T) {
+func TestGenerator_GenerateContract_AdminEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		decl  string
+		emit  string
+	}{
+		{"admin burn", func(c *config.TokenConfig) { c.AdminBurn = true },
+			"event AdminBurned(address indexed operator, address indexed from, uint256 amount);",
+			"emit AdminBurned(_msgSender(), from, amount);"},
+		{"locks", func(c *config.TokenConfig) { c.Locks = true },
+			"event BalanceLocked(address indexed operator, address indexed account, uint256 amount, uint64 releaseTime);",
+			"emit BalanceLocked(_msgSender(), account, amount, releaseTime);"},
+		{"mint schedule", func(c *config.TokenConfig) {
+			c.Mintable = true
+			c.MintSchedule = config.MintScheduleLinear
+			c.EmissionRatePerSecond = "2"
+			c.EmissionStart = 1767225600
+		},
+			"event ScheduledMint(address indexed to, uint256 amount, uint256 totalMinted);",
+			"emit ScheduledMint(to, amount, scheduledMinted);"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			tt.setup(cfg)
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, tt.decl)
+			assert.Contains(t, contract, tt.emit)
+
+			cfg.OmitEvents = true
+			contract, err = generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.NotContains(t, contract, tt.decl)
+			assert.NotContains(t, contract, tt.emit)
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_Airdrop(t *testing.T) {
+	tests := []struct {
+		name       string
+		access     config.AccessControlType
+		restricted bool
+		signature  string
+	}{
+		{"open", config.AccessOwnable, false, "function batchTransfer(address[] calldata recipients, uint256[] calldata amounts) external {"},
+		{"owner only", config.AccessOwnable, true, "function batchTransfer(address[] calldata recipients, uint256[] calldata amounts) external onlyOwner {"},
+		{"admin role only", config.AccessRoles, true, "function batchTransfer(address[] calldata recipients, uint256[] calldata amounts) external onlyRole(DEFAULT_ADMIN_ROLE) {"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = tt.access
+			cfg.Airdrop = true
+			cfg.AirdropRestricted = tt.restricted
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, tt.signature)
+			assert.Contains(t, contract, "error BatchLengthMismatch(uint256 recipients, uint256 amounts);")
+			assert.Contains(t, contract, "if (recipients.length != amounts.length) {\n            revert BatchLengthMismatch(recipients.length, amounts.length);")
+			assert.Contains(t, contract, "_transfer(sender, recipients[i], amounts[i]);")
+		})
+	}
+}
+
+func TestTokenConfig_Validate_AirdropRestricted(t *testing.T) {
 	cfg := baseConfig()
-	cfg.Decimals = 18
+	cfg.AirdropRestricted = true
+	require.ErrorContains(t, cfg.Validate(), "requires --with-airdrop")
+
+	cfg = baseConfig()
+	cfg.Airdrop = true
+	cfg.AirdropRestricted = true
+	cfg.AccessControl = config.AccessNone
+	require.ErrorContains(t, cfg.Validate(), "needs access control")
+}
+
+func TestGenerator_GenerateContract_Bridge(t *testing.T) {
+	cfg := baseConfig()
+	cfg.BridgeMinter = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+	cfg.Burnable = true
 	require.NoError(t, cfg.Validate())
 
-	gen := generator.New(cfg)
-	contract, err := gen.GenerateContract()
+	contract, err := generator.New(cfg).GenerateContract()
 	require.NoError(t, err)
 
-	// Standard decimals should NOT generate an override function
-	assert.NotContains(t, contract, "function decimals()")
+	assert.Contains(t, contract, "address public constant BRIDGE = 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed;")
+	assert.Contains(t, contract, "if (_msgSender() != BRIDGE) {\n            revert UnauthorizedBridge(_msgSender());")
+	assert.Contains(t, contract, "function mint(address to, uint256 amount) external onlyBridge {")
+	assert.Contains(t, contract, "function burn(address from, uint256 amount) external onlyBridge {")
+	assert.Contains(t, contract, "_spendAllowance(from, BRIDGE, amount);")
+	// Bridge gating is independent of the owner.
+	assert.NotContains(t, contract, "external onlyOwner {\n        _mint")
 }
 
-func TestGenerator_GenerateContract_WithCap(t *testing.T) {
+func TestTokenConfig_Validate_Bridge(t *testing.T) {
+	tests := []struct {
+		name   string
+		bridge string
+		setup  func(c *config.TokenConfig)
+		want   string
+	}{
+		{"not hex", "0xnothex", nil, "not a 0x-prefixed"},
+		{"zero address", "0x0000000000000000000000000000000000000000", nil, "zero address"},
+		{"bad checksum", "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil, "EIP-55 checksum"},
+		{"mintable", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", func(c *config.TokenConfig) { c.Mintable = true }, "both define mint"},
+		{"fixed supply", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", func(c *config.TokenConfig) { c.FixedSupply = true }, "fixed supply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.BridgeMinter = tt.bridge
+			if tt.setup != nil {
+				tt.setup(cfg)
+			}
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+const testMerkleRoot = "0xAB00000000000000000000000000000000000000000000000000000000000001"
+
+func TestGenerator_GenerateContract_MerkleClaim(t *testing.T) {
 	cfg := baseConfig()
-	cfg.MaxSupply = "10000000"
+	cfg.MerkleClaim = true
+	cfg.MerkleRoot = testMerkleRoot
 	require.NoError(t, cfg.Validate())
 
-	gen := generator.New(cfg)
-	contract, err := gen.GenerateContract()
+	contract, err := generator.New(cfg).GenerateContract()
 	require.NoError(t, err)
 
-	assert.Contains(t, contract, "ERC20Capped")
-	assert.Contains(t, contract, "10000000")
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/utils/cryptography/MerkleProof.sol";`)
+	assert.Contains(t, contract, "bytes32 public constant MERKLE_ROOT = 0xab00000000000000000000000000000000000000000000000000000000000001;")
+	assert.Contains(t, contract, "mapping(address => bool) public claimed;")
+	assert.Contains(t, contract, "function claim(uint256 amount, bytes32[] calldata proof) external {")
+	assert.Contains(t, contract, "if (claimed[account]) {\n            revert AlreadyClaimed(account);")
+	assert.Contains(t, contract, "if (!MerkleProof.verifyCalldata(proof, MERKLE_ROOT, leaf)) {\n            revert InvalidMerkleProof(account, amount);")
+	// The claim is recorded before minting.
+	assert.Contains(t, contract, "claimed[account] = true;\n        _mint(account, amount);\n        emit Claimed(account, amount);")
+
+	cfg.RequireStrings = true
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `require(!claimed[account], "TestToken: already claimed");`)
+	assert.NotContains(t, contract, "error AlreadyClaimed")
 }
 
-func TestGenerator_GenerateContract_PermitIncluded(t *testing.T) {
+func TestGenerator_GenerateContract_MerkleClaimUpgradeable(t *testing.T) {
 	cfg := baseConfig()
-	cfg.Permit = true
+	cfg.MerkleClaim = true
+	cfg.MerkleRoot = testMerkleRoot
+	cfg.Upgradeable = config.UpgradeUUPS
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	// MerkleProof is a library, imported from the plain package either way.
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/utils/cryptography/MerkleProof.sol";`)
+	assert.NotContains(t, contract, "MerkleProofUpgradeable")
+}
+
+func TestTokenConfig_Validate_MerkleClaim(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		want  string
+	}{
+		{"missing root", func(c *config.TokenConfig) { c.MerkleClaim = true }, "requires --merkle-root"},
+		{"root without claim", func(c *config.TokenConfig) { c.MerkleRoot = testMerkleRoot }, "requires --with-merkle-claim"},
+		{"short root", func(c *config.TokenConfig) { c.MerkleClaim, c.MerkleRoot = true, "0xab" }, "64 hex characters"},
+		{"not hex", func(c *config.TokenConfig) { c.MerkleClaim, c.MerkleRoot = true, "0x"+strings.Repeat("zz", 32) }, "64 hex characters"},
+		{"zero root", func(c *config.TokenConfig) { c.MerkleClaim, c.MerkleRoot = true, "0x"+strings.Repeat("0", 64) }, "must not be zero"},
+		{"fixed supply", func(c *config.TokenConfig) {
+			c.MerkleClaim, c.MerkleRoot, c.FixedSupply = true, testMerkleRoot, true
+		}, "claims mint new tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			tt.setup(cfg)
+			require.ErrorContains(t, cfg.Validate(), tt.want)
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_Metadata(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Metadata = true
+	cfg.MetadataURI = "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/token.json"
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "string private _contractURI;")
+	assert.Contains(t, contract, `_contractURI = "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi/token.json";`)
+	assert.Contains(t, contract, "function contractURI() external view returns (string memory) {")
+	assert.Contains(t, contract, "function setContractURI(string calldata newURI) external onlyOwner {")
+	assert.Contains(t, contract, "emit ContractURIUpdated();")
+
+	cfg.AccessControl = config.AccessRoles
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.MetadataURI = ""
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "function setContractURI(string calldata newURI) external onlyRole(DEFAULT_ADMIN_ROLE) {")
+	assert.NotContains(t, contract, `_contractURI = "`)
+}
+
+func TestTokenConfig_Validate_Metadata(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		want  string
+	}{
+		{"not a URI", func(c *config.TokenConfig) { c.Metadata, c.MetadataURI = true, "token metadata" }, "invalid metadata URI"},
+		{"other scheme", func(c *config.TokenConfig) { c.Metadata, c.MetadataURI = true, "ftp://example.com/t.json" }, "invalid metadata URI"},
+		{"quote", func(c *config.TokenConfig) { c.Metadata, c.MetadataURI = true, `https://example.com/"t.json` }, "invalid metadata URI"},
+		{"URI without metadata", func(c *config.TokenConfig) { c.MetadataURI = "https://example.com/t.json" }, "requires --with-metadata"},
+		{"no access control", func(c *config.TokenConfig) { c.Metadata, c.AccessControl = true, config.AccessNone }, "metadata requires access control"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			tt.setup(cfg)
+			require.ErrorContains(t, cfg.Validate(), tt.want)
+		})
+	}
+
+	cfg := baseConfig()
+	cfg.Metadata, cfg.MetadataURI = true, "https://example.com/token.json"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestGenerator_GenerateContract_BurnRebase(t *testing.T) {
+	cfg := baseConfig()
+	cfg.BurnRebase = true
+	cfg.BurnRebaseMaxBps = 500
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "uint256 public constant MAX_BURN_REBASE_BPS = 500;")
+	assert.Contains(t, contract, "uint256 private _rebaseIndex = 1e18;")
+	assert.Contains(t, contract, "function applyBurnRebase(uint256 bps) external onlyOwner {")
+	assert.Contains(t, contract, "if (bps == 0 || bps > MAX_BURN_REBASE_BPS) {\n            revert InvalidBurnRebase(bps);")
+	// Proportional reduction: one index write scales every balance.
+	assert.Contains(t, contract, "uint256 newIndex = _rebaseIndex * (10_000 - bps) / 10_000;")
+	assert.Contains(t, contract, "return super.balanceOf(account) * _rebaseIndex / 1e18;")
+	assert.Contains(t, contract, "return super.totalSupply() * _rebaseIndex / 1e18;")
+	assert.Contains(t, contract, "override(ERC20)")
+	assert.Contains(t, contract, "super._update(from, to, value * 1e18 / _rebaseIndex);")
+	assert.Contains(t, contract, "emit BurnRebase(_msgSender(), bps, newIndex);")
+
+	cfg.AccessControl = config.AccessRoles
+	cfg.RequireStrings = true
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "function applyBurnRebase(uint256 bps) external onlyRole(DEFAULT_ADMIN_ROLE) {")
+	assert.Contains(t, contract, `require(bps != 0 && bps <= MAX_BURN_REBASE_BPS, "TestToken: invalid burn rebase");`)
+	assert.NotContains(t, contract, "error InvalidBurnRebase")
+}
+
+func TestGenerator_BurnRebase_DocumentsShareTransfers(t *testing.T) {
+	cfg := baseConfig()
+	cfg.BurnRebase = true
+	cfg.BurnRebaseMaxBps = config.DefaultBurnRebaseMaxBps
+	require.NoError(t, cfg.Validate())
+
+	tests, err := generator.New(cfg).GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, tests, `.withArgs(addr1.address, owner.address, 100n);`, "Transfer reports shares, not the 99 tokens sent")
+	assert.Contains(t, tests, "expect(await token.balanceOf(addr1.address)).to.equal(0n);")
+
+	cfg.TestStyle = config.TestStyleViemTS
+	tests, err = generator.New(cfg).GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, tests, "expect(transfer.args.value).to.equal(100n);")
+
+	readme, err := generator.New(cfg).GenerateReadme(generator.ProjectFiles{Layout: "hardhat", Contract: "contracts/TestToken.sol"})
+	require.NoError(t, err)
+	assert.Contains(t, readme, "### Burn rebase")
+	assert.Contains(t, readme, "`Transfer` events carry the shares moved, not the token amount.")
+}
+
+func TestTokenConfig_Validate_BurnRebase(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		want  string
+	}{
+		{"zero limit", func(c *config.TokenConfig) { c.BurnRebaseMaxBps = 0 }, "must be between 1 and 9999"},
+		{"whole supply", func(c *config.TokenConfig) { c.BurnRebaseMaxBps = 10000 }, "must be between 1 and 9999"},
+		{"capped", func(c *config.TokenConfig) { c.MaxSupply = "2000000" }, "conflicts with --max-supply"},
+		{"votes", func(c *config.TokenConfig) { c.Votes = true }, "conflicts with votes"},
+		{"snapshot", func(c *config.TokenConfig) { c.Snapshot = true }, "conflicts with snapshot"},
+		{"upgradeable", func(c *config.TokenConfig) { c.Upgradeable = config.UpgradeUUPS }, "not supported for upgradeable tokens"},
+		{"oz v4", func(c *config.TokenConfig) { c.OZVersion = config.OZv4 }, "requires --oz-version 5"},
+		{"no access control", func(c *config.TokenConfig) { c.AccessControl = config.AccessNone }, "a burn rebase requires access control"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.BurnRebase = true
+			cfg.BurnRebaseMaxBps = config.DefaultBurnRebaseMaxBps
+			tt.setup(cfg)
+			require.ErrorContains(t, cfg.Validate(), tt.want)
+		})
+	}
+}
+
+func TestTokenConfig_Validate_AdminBurnConflicts(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AdminBurn = true
+	cfg.Burnable = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "admin burn cannot be combined with burnable")
+
+	cfg = baseConfig()
+	cfg.AdminBurn = true
+	cfg.AccessControl = config.AccessNone
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "admin burn requires access control")
+}
+
+func TestGenerator_GenerateContract_StartPausedPausesInConstructor(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Pausable = true
+	cfg.StartPaused = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	ctor := contract[strings.Index(contract, "constructor("):]
+	ctor = ctor[:strings.Index(ctor, "\n    }\n")]
+	assert.Contains(t, ctor, "_pause();")
+	assert.Less(t, strings.Index(ctor, "_mint("), strings.Index(ctor, "_pause();"), "initial mint must precede _pause()")
+
+	script, err := gen.GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, "unpause()")
+}
+
+func TestTokenConfig_Validate_StartPausedRequiresPausable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.StartPaused = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "start paused requires the pausable feature")
+}
+
+func TestGenerator_GenerateContract_RolesAccessControl(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessRoles
+	cfg.Mintable = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "MINTER_ROLE")
+	assert.Contains(t, contract, "onlyRole(MINTER_ROLE)")
+	assert.Contains(t, contract, "AccessControl")
+	assert.NotContains(t, contract, "onlyOwner")
+}
+
+func TestGenerator_AdminAddress_GrantsRolesToAdmin(t *testing.T) {
+	const admin = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessRoles
+	cfg.AdminAddress = strings.ToLower(admin)
+	cfg.Mintable = true
+	cfg.AdminBurn = true
+	cfg.Pausable = true
+	cfg.Snapshot = true
+	cfg.Locks = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	for _, role := range []string{"DEFAULT_ADMIN_ROLE", "MINTER_ROLE", "PAUSER_ROLE", "SNAPSHOT_ROLE", "BURNER_ROLE", "LOCKER_ROLE"} {
+		assert.Contains(t, contract, "_grantRole("+role+", defaultAdmin);")
+	}
+
+	script, err := gen.GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, `.deploy("`+admin+`")`)
+
+	cfg.DeployStyle = config.DeployStyleHardhatDeploy
+	script, err = generator.New(cfg).GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, `args: ["`+admin+`"]`)
+}
+
+func TestTokenConfig_Validate_AdminAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  config.AccessControlType
+		address string
+		wantErr string
+	}{
+		{"roles", config.AccessRoles, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ""},
+		{"not an address", config.AccessRoles, "0x1234", "admin address"},
+		{"ownable", config.AccessOwnable, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "--admin-address requires --access roles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = tt.access
+			cfg.AdminAddress = tt.address
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_DisplayName_Unicode(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Name = "Cafe Coin"
+	cfg.DisplayName = "Café Coin"
+	cfg.Permit = true
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "Cafe_Coin", cfg.SafeName())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "contract Cafe_Coin is ")
+	assert.Contains(t, contract, `ERC20(unicode"Café Coin", "TST")`)
+	assert.Contains(t, contract, `ERC20Permit(unicode"Café Coin")`)
+	assert.NotContains(t, contract, `"Cafe Coin"`)
+
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, `expect(await token.name()).to.equal("Café Coin");`)
+
+	// ASCII display names need no unicode prefix.
+	cfg.DisplayName = "Cafe Coin (Bridged)"
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `ERC20("Cafe Coin (Bridged)", "TST")`)
+}
+
+func TestTokenConfig_Validate_DisplayName(t *testing.T) {
+	tests := []struct {
+		name, display, wantErr string
+	}{
+		{"unicode", "Café Coin ☕", ""},
+		{"cjk", "日本円トークン", ""},
+		{"too long", strings.Repeat("é", config.MaxDisplayNameLength+1), "too long"},
+		{"quote", `Say "hi"`, "must not contain"},
+		{"backslash", `a\b`, "must not contain"},
+		{"newline", "Café\nCoin", "control characters"},
+		{"padded", " Café", "whitespace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.DisplayName = tt.display
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTokenConfig_Validate_NonASCIINameSuggestsDisplayName(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Name = "Café Coin"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--display-name")
+}
+
+func TestGenerator_GenerateContract_NoAccessControl(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessNone
+	cfg.Burnable = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.NotContains(t, contract, "onlyOwner")
+	assert.NotContains(t, contract, "onlyRole")
+	assert.NotContains(t, contract, "Ownable")
+}
+
+func TestGenerator_GenerateContract_CustomDecimals(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Decimals = 6
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "function decimals()")
+	assert.Contains(t, contract, "return 6;")
+}
+
+func TestGenerator_GenerateContract_Standard18DecimalsNoOverride(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Decimals = 18
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	// Standard decimals should NOT generate an override function
+	assert.NotContains(t, contract, "function decimals()")
+}
+
+func TestGenerator_GenerateContract_WithCap(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MaxSupply = "10000000"
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "ERC20Capped")
+	// 10,000,000 whole tokens at 18 decimals, precomputed as a literal.
+	assert.Contains(t, contract, "ERC20Capped(10_000_000_000_000_000_000_000_000)")
+	assert.NotContains(t, contract, "ERC20Capped(10_000_000 *")
+}
+
+func TestGenerator_GenerateContract_CapScalesWithDecimals(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Decimals = 6
+	cfg.InitialSupply = "1000000"
+	cfg.MaxSupply = "1000000"
+	require.NoError(t, cfg.Validate(), "initial == max must compare whole tokens")
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "ERC20Capped(1_000_000_000_000)")
+}
+
+func TestGenerator_GenerateContract_SeparatesNumericLiterals(t *testing.T) {
+	tests := []struct {
+		supply, cap string
+		wantMint    string
+		wantCap     string
+	}{
+		{"1000000000", "1000000000", "_mint(initialOwner, 1_000_000_000 * 10 ** decimals());", "ERC20Capped(1_000_000_000_000_000_000_000_000_000)"},
+		{"1000", "10000", "_mint(initialOwner, 1_000 * 10 ** decimals());", "ERC20Capped(10_000_000_000_000_000_000_000)"},
+		{"999", "999", "_mint(initialOwner, 999 * 10 ** decimals());", "ERC20Capped(999_000_000_000_000_000_000)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.supply, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.InitialSupply = tt.supply
+			cfg.MaxSupply = tt.cap
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, tt.wantMint)
+			assert.Contains(t, contract, tt.wantCap)
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_CappedVotesOwnableUpdateOverride(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MaxSupply = "10000000"
+	cfg.Votes = true
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, config.AccessOwnable, cfg.AccessControl)
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "contract TestToken is ERC20, ERC20Capped, ERC20Permit, ERC20Votes, Ownable {")
+	assert.Equal(t, 1, strings.Count(contract, "function _update("))
+	assert.Equal(t, 1, strings.Count(contract, "super._update("))
+	// Every parent that declares _update, and nothing else (Ownable, ERC20Permit do not).
+	assert.Contains(t, contract, "override(ERC20, ERC20Capped, ERC20Votes)\n")
+}
+
+func TestGenerator_GenerateContract_CappedPausableVotesSingleUpdateOverride(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MaxSupply = "10000000"
+	cfg.Mintable = true
+	cfg.Pausable = true
+	cfg.Snapshot = true
+	cfg.Votes = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, strings.Count(contract, "function _update("), "expected exactly one _update override")
+	assert.Contains(t, contract, "override(ERC20, ERC20Capped, ERC20Pausable, ERC20Snapshot, ERC20Votes)")
+	assert.Contains(t, contract, "super._update(from, to, value);")
+	// mint() goes through _mint → _update, so the cap is enforced by ERC20Capped.
+	assert.Contains(t, contract, "_mint(to, amount);")
+}
+
+func TestGenerator_GenerateContract_VotesOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		oz        config.OZVersion
+		clock     config.ClockMode
+		nonces    bool
+		timestamp bool
+	}{
+		{"permit + blocknumber", config.OZv5, config.ClockBlockNumber, true, false},
+		{"permit + timestamp", config.OZv5, config.ClockTimestamp, true, true},
+		// v4 ERC20Votes keeps its own nonces in ERC20Permit and hooks
+		// _afterTokenTransfer instead of _update.
+		{"v4 + timestamp", config.OZv4, config.ClockTimestamp, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Votes = true
+			cfg.ClockMode = tt.clock
+			cfg.OZVersion = tt.oz
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+
+			assert.Contains(t, contract, `ERC20Permit("TestToken")`)
+			assert.Contains(t, contract, "(clock: "+string(tt.clock)+")")
+			if tt.nonces {
+				assert.Contains(t, contract, "function _update(address from, address to, uint256 value)")
+				assert.Contains(t, contract, "override(ERC20Permit, Nonces)")
+			} else {
+				assert.NotContains(t, contract, "function nonces(")
+				assert.NotContains(t, contract, "_update(")
+				assert.Contains(t, contract, "function _afterTokenTransfer(address from, address to, uint256 value)")
+				assert.Contains(t, contract, "override(ERC20, ERC20Votes)")
+			}
+			if tt.timestamp {
+				assert.Contains(t, contract, "return uint48(block.timestamp);")
+				assert.Contains(t, contract, `return "mode=timestamp";`)
+			} else {
+				assert.NotContains(t, contract, "function clock()")
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_ClockMode(t *testing.T) {
+	for _, oz := range []config.OZVersion{config.OZv4, config.OZv5} {
+		t.Run("v"+string(oz)+" timestamp", func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.OZVersion = oz
+			cfg.Votes = true
+			cfg.ClockMode = config.ClockTimestamp
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, "function clock() public view override returns (uint48) {\n        return uint48(block.timestamp);\n    }")
+			assert.Contains(t, contract, "function CLOCK_MODE() public pure override returns (string memory) {\n        return \"mode=timestamp\";\n    }")
+			assert.NotContains(t, contract, "block.number")
+		})
+
+		t.Run("v"+string(oz)+" blocknumber", func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.OZVersion = oz
+			cfg.Votes = true
+			cfg.ClockMode = config.ClockBlockNumber
+			require.NoError(t, cfg.Validate())
+
+			// ERC20Votes already keys checkpoints by block.number and reports
+			// "mode=blocknumber&from=default", so nothing is overridden.
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, "(clock: blocknumber)")
+			assert.NotContains(t, contract, "function clock()")
+			assert.NotContains(t, contract, "function CLOCK_MODE()")
+		})
+	}
+}
+
+func TestTokenConfig_Validate_TimestampClockRequiresVotes(t *testing.T) {
+	cfg := baseConfig()
+	cfg.ClockMode = config.ClockTimestamp
+	err := cfg.Validate()
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Contains(t, err.Error(), "timestamp clock mode requires --votes")
+
+	// The blocknumber default is accepted without Votes: it adds no code.
+	cfg = baseConfig()
+	cfg.ClockMode = config.ClockBlockNumber
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestTokenConfig_Validate_InvalidClockMode(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Votes = true
+	cfg.ClockMode = "epoch"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid clock mode")
+}
+
+func TestGenerator_GenerateContract_LinearMintSchedule(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "2"
+	cfg.EmissionStart = 1767225600
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "uint256 public constant EMISSION_RATE = 2_000_000_000_000_000_000;")
+	assert.Contains(t, contract, "uint256 public constant EMISSION_START = 1767225600;")
+	assert.Contains(t, contract, "(block.timestamp - EMISSION_START) * EMISSION_RATE")
+	assert.Contains(t, contract, "revert EmissionScheduleExceeded(amount, available);")
+	assert.Contains(t, contract, "scheduledMinted += amount;")
+}
+
+func TestGenerator_GenerateContract_OptimizeUsesUnchecked(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "2"
+	cfg.EmissionStart = 1767225600
+	require.NoError(t, cfg.Validate())
+
+	plain, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "unchecked {")
+
+	cfg.Optimize = true
+	optimized, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(optimized, "unchecked {"))
+	assert.Contains(t, optimized, "// unchecked: block.timestamp > EMISSION_START was checked above.")
+	// The multiplication is not provably safe and must stay checked.
+	assert.Contains(t, optimized, "uint256 accrued = elapsed * EMISSION_RATE;")
+}
+
+func TestGenerator_GenerateContract_ExtractLibraries(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "2"
+	cfg.EmissionStart = 1767225600
+	require.NoError(t, cfg.Validate())
+
+	inline, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, inline, "library ")
+	assert.NotContains(t, inline, "using EmissionSchedule")
+
+	cfg.ExtractLibraries = true
+	g := generator.New(cfg)
+	contract, err := g.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "library EmissionSchedule {")
+	assert.Contains(t, contract, "function available(uint256 start, uint256 rate, uint256 minted) public view returns (uint256)")
+	assert.Contains(t, contract, "using EmissionSchedule for uint256;")
+	assert.Contains(t, contract, "return EMISSION_START.available(EMISSION_RATE, scheduledMinted);")
+	assert.Less(t, strings.Index(contract, "library EmissionSchedule"), strings.Index(contract, "\ncontract TestToken is"))
+
+	deploy, err := g.GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, deploy, "libraries: { EmissionSchedule: await EmissionScheduleLib.getAddress() }")
+}
+
+func TestGenerator_GenerateContract_NoMintScheduleByDefault(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "EMISSION_RATE")
+	assert.NotContains(t, contract, "mintableAmount")
+}
+
+func TestTokenConfig_Validate_MintSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    string
+		start   int64
+		wantErr string
+	}{
+		{"zero rate", "0", 1767225600, "emission rate must be greater than zero"},
+		{"negative rate", "-1", 1767225600, "emission rate"},
+		{"missing rate", "", 1767225600, "emission rate"},
+		{"zero start", "1", 0, "emission start must be a positive unix timestamp"},
+		{"negative start", "1", -5, "emission start"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Mintable = true
+			cfg.MintSchedule = config.MintScheduleLinear
+			cfg.EmissionRatePerSecond = tt.rate
+			cfg.EmissionStart = tt.start
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTokenConfig_Validate_MintScheduleRequiresMintable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "1"
+	cfg.EmissionStart = 1767225600
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires the mintable feature")
+}
+
+func TestGenerator_GenerateContract_Explain(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Pausable = true
+	cfg.Votes = true
+	require.NoError(t, cfg.Validate())
+
+	plain, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "// ERC20Pausable:")
+	assert.NotContains(t, plain, "Why each parent contract is inherited")
+
+	cfg.Explain = true
+	explained, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, explained, "// ERC20Pausable: overrides _update to block transfers while paused")
+	assert.Contains(t, explained, "// ERC20Votes: overrides _update to move voting power checkpoints")
+	assert.Contains(t, explained, "// Ownable: a single owner account guards privileged functions")
+}
+
+func TestGenerator_GenerateContract_ExplainUpgradeable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Pausable = true
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.Explain = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "// Initializable: replaces the constructor")
+	assert.Contains(t, contract, "// ERC20PausableUpgradeable: overrides _update")
+	assert.Contains(t, contract, "// UUPSUpgradeable: upgrade logic lives in the implementation")
+}
+
+func TestGenerator_GenerateContract_CustomDecimalsWithPermitAndVotes(t *testing.T) {
+	for _, upgradeable := range []config.UpgradeableType{config.UpgradeNone, config.UpgradeUUPS} {
+		t.Run(string(upgradeable), func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Decimals = 6
+			cfg.Permit = true
+			cfg.Votes = true
+			cfg.Upgradeable = upgradeable
+			require.NoError(t, cfg.Validate())
+
+			contract, err := generator.New(cfg).GenerateContract()
+			require.NoError(t, err)
+
+			assert.Contains(t, contract, "function decimals() public pure override returns (uint8)")
+			assert.Contains(t, contract, "return 6;")
+			// Scaling happens exactly once, in the initial mint. Permit and
+			// checkpoint code must keep operating on raw base units.
+			assert.Equal(t, 1, strings.Count(contract, "10 ** decimals()"))
+			assert.NotContains(t, contract, "10 ** 6")
+			assert.Contains(t, contract, "return super.nonces(owner);")
+			assert.Contains(t, contract, "super._update(from, to, value);")
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_Minify(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.Pausable = true
+	cfg.Minify = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(contract, "// SPDX-License-Identifier: MIT\n"))
+	assert.Contains(t, contract, "pragma solidity ^0.8.24;")
+	assert.Contains(t, contract, "function mint(address to, uint256 amount) external onlyOwner {")
+	assert.NotContains(t, contract, "/**")
+	assert.NotContains(t, contract, "@dev")
+	assert.NotContains(t, contract, "Generated by erc20gen")
+	assert.NotContains(t, contract, "\n\n")
+}
+
+func TestGenerator_GenerateContract_Style(t *testing.T) {
+	render := func(style config.ContractStyle) string {
+		cfg := baseConfig()
+		cfg.AccessControl = config.AccessRoles
+		cfg.Mintable = true
+		cfg.Pausable = true
+		cfg.Style = style
+		require.NoError(t, cfg.Validate())
+		contract, err := generator.New(cfg).GenerateContract()
+		require.NoError(t, err)
+		return contract
+	}
+	standard := render(config.StyleStandard)
+	minimal := render(config.StyleMinimal)
+	verbose := render(config.StyleVerbose)
+
+	assert.Less(t, len(minimal), len(standard))
+	assert.Less(t, len(standard), len(verbose))
+
+	assert.NotContains(t, minimal, "/*")
+	assert.NotContains(t, minimal, "@title")
+	for _, line := range strings.Split(minimal, "\n") {
+		if strings.Contains(line, "//") {
+			assert.True(t, strings.HasPrefix(line, "// SPDX-License-Identifier:"), "comment left in minimal output: %q", line)
+		}
+	}
+	assert.Contains(t, minimal, "function mint(address to, uint256 amount) external onlyRole(MINTER_ROLE) {")
+
+	assert.Contains(t, standard, " * @title TestToken")
+	assert.NotContains(t, standard, "Why each parent contract is inherited")
+	assert.NotContains(t, standard, "Privileged functions")
+
+	assert.Contains(t, verbose, " * @title TestToken")
+	assert.Contains(t, verbose, "     * @dev Mints `amount` tokens to `to`.")
+	assert.Contains(t, verbose, "// Why each parent contract is inherited:")
+	assert.Contains(t, verbose, " * Privileged functions:\n *   mint(address,uint256)       — MINTER_ROLE holders\n *   pause, unpause              — PAUSER_ROLE holders\n */")
+}
+
+func TestTokenConfig_Validate_Style(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, config.StyleStandard, cfg.Style)
+
+	cfg.Style = "terse"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid style "terse"`)
+
+	cfg.Style = config.StyleMinimal
+	cfg.Explain = true
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--explain conflicts with --style minimal")
+
+	cfg.Style = config.StyleVerbose
+	cfg.Explain = false
+	cfg.Minify = true
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--minify conflicts with --style verbose")
+}
+
+func TestGenerator_GenerateContract_Provenance(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.Pausable = true
+	require.NoError(t, cfg.Validate())
+
+	plain, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "// provenance:")
+
+	cfg.Provenance = true
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "// provenance: erc20gen v"+generator.Version)
+	assert.Contains(t, contract, "// provenance: features Mintable, Pausable")
+	assert.Regexp(t, `// provenance: config-sha256 [0-9a-f]{64}\n`, contract)
+
+	hash, err := cfg.ConfigHash()
+	require.NoError(t, err)
+	assert.Contains(t, contract, hash)
+}
+
+func TestTokenConfig_ConfigHash_Stable(t *testing.T) {
+	a, b := baseConfig(), baseConfig()
+	b.Provenance = true
+	ha, err := a.ConfigHash()
+	require.NoError(t, err)
+	hb, err := b.ConfigHash()
+	require.NoError(t, err)
+	assert.Equal(t, ha, hb, "toggling provenance must not change the hash")
+
+	b.Decimals = 6
+	hb, err = b.ConfigHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, ha, hb)
+}
+
+func TestGenerator_GenerateContract_NoUpdateOverrideWithoutHookingExtensions(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Burnable = true
+	cfg.Permit = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.NotContains(t, contract, "function _update(")
+}
+
+func TestGenerator_GenerateContract_CappedWithoutAccessControl(t *testing.T) {
+	cfg := baseConfig()
+	cfg.AccessControl = config.AccessNone
+	cfg.MaxSupply = "10000000"
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "ERC20Capped(10_000_000_000_000_000_000_000_000)")
+}
+
+func TestGenerator_GenerateContract_CappedNotMintable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.InitialSupply = "1000"
+	cfg.MaxSupply = "5000"
+	require.NoError(t, cfg.Validate())
+	require.False(t, cfg.Mintable)
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "contract TestToken is ERC20, ERC20Capped, Ownable")
+	assert.Contains(t, contract, "        ERC20(\"TestToken\", \"TST\")\n        ERC20Capped(5_000_000_000_000_000_000_000)\n")
+	assert.Contains(t, contract, "_mint(initialOwner, 1_000 * 10 ** decimals());")
+	assert.Contains(t, contract, "override(ERC20, ERC20Capped)", "ERC20 and ERC20Capped both define _update")
+	assert.NotContains(t, contract, "function mint(")
+}
+
+func TestTokenConfig_Validate_RejectsZeroCap(t *testing.T) {
+	cfg := baseConfig()
+	cfg.InitialSupply = ""
+	cfg.MaxSupply = "0"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max supply must be greater than zero")
+}
+
+func TestGenerator_GenerateContract_FixedSupply(t *testing.T) {
+	cfg := baseConfig()
+	cfg.FixedSupply = true
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, cfg.InitialSupply, cfg.MaxSupply)
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "ERC20Capped(1_000_000_000_000_000_000_000_000)")
+	assert.Contains(t, contract, "_mint(initialOwner, 1_000_000 * 10 ** decimals());")
+	assert.NotContains(t, contract, "function mint(")
+}
+
+func TestTokenConfig_Validate_FixedSupplyConflicts(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(c *config.TokenConfig)
+		field string
+	}{
+		{"mintable", func(c *config.TokenConfig) { c.Mintable = true }, "Mintable"},
+		{"different cap", func(c *config.TokenConfig) { c.MaxSupply = "2000000" }, "MaxSupply"},
+		{"no initial supply", func(c *config.TokenConfig) { c.InitialSupply = "" }, "FixedSupply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.FixedSupply = true
+			tt.setup(cfg)
+
+			err := cfg.Validate()
+			var verr *config.ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tt.field, verr.Fields[0].Field)
+		})
+	}
+}
+
+func TestGenerator_GenerateDeployScript_HardhatDeploy(t *testing.T) {
+	cfg := baseConfig()
+	cfg.DeployStyle = config.DeployStyleHardhatDeploy
+	require.NoError(t, cfg.Validate())
+
+	script, err := generator.New(cfg).GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, "const { deployer } = await getNamedAccounts();")
+	assert.Contains(t, script, `await deploy("TestToken", {`)
+	assert.Contains(t, script, "args: [deployer],")
+	assert.Contains(t, script, "module.exports = func;")
+	assert.Contains(t, script, `func.tags = ["TestToken"];`)
+	assert.NotContains(t, script, "getContractFactory")
+}
+
+func TestTokenConfig_Validate_InvalidDeployStyle(t *testing.T) {
+	cfg := baseConfig()
+	cfg.DeployStyle = "truffle"
+	var verr *config.ValidationError
+	require.ErrorAs(t, cfg.Validate(), &verr)
+	assert.Equal(t, "DeployStyle", verr.Fields[0].Field)
+}
+
+func TestGenerator_GenerateContractCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	src, err := generator.New(baseConfig()).GenerateContractCtx(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, src)
+}
+
+func TestGenerator_GenerateTo_MatchesStringMethods(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.Pausable = true
+	// Placeholders consume the seed, so each side gets a fresh generator.
+	gen := func() *generator.Generator { return generator.NewWithSeed(cfg, 1) }
+
+	for _, style := range []config.ContractStyle{config.StyleStandard, config.StyleMinimal} {
+		cfg.Style = style
+		want, err := gen().GenerateContract()
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, gen().GenerateContractTo(&buf))
+		assert.Equal(t, want, buf.String(), style)
+	}
+
+	want, err := gen().GenerateDeployScript()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, gen().GenerateDeployScriptTo(&buf))
+	assert.Equal(t, want, buf.String())
+
+	want, err = gen().GenerateTestSkeleton()
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, gen().GenerateTestSkeletonTo(&buf))
+	assert.Equal(t, want, buf.String())
+}
+
+func TestGenerator_GenerateContractToCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	require.ErrorIs(t, generator.New(baseConfig()).GenerateContractToCtx(ctx, &buf), context.Canceled)
+	assert.Zero(t, buf.Len())
+}
+
+func TestGenerator_GenerateContract_InitialHolder(t *testing.T) {
+	const holder = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+	tests := []struct {
+		name     string
+		holder   string
+		wantMint string
+	}{
+		{"configured holder", holder, "_mint(0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed, 1_000_000 * 10 ** decimals());"},
+		{"unset falls back to deployer", "", "_mint(msg.sender, 1_000_000 * 10 ** decimals());"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.AccessControl = config.AccessNone
+			cfg.InitialHolder = tt.holder
+			require.NoError(t, cfg.Validate())
+
+			g := generator.New(cfg)
+			contract, err := g.GenerateContract()
+			require.NoError(t, err)
+			assert.Contains(t, contract, tt.wantMint)
+
+			deploy, err := g.GenerateDeployScript()
+			require.NoError(t, err)
+			assert.Contains(t, deploy, "Minted to:")
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_Allocations(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Allocations = []config.Allocation{
+		{Address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", Amount: "600000"},
+		{Address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", Amount: "300000"},
+		{Address: "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB", Amount: "100000"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	g := generator.New(cfg)
+	contract, err := g.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "_mint(0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed, 600_000 * 10 ** decimals());")
+	assert.Contains(t, contract, "_mint(0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359, 300_000 * 10 ** decimals());")
+	assert.Contains(t, contract, "_mint(0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB, 100_000 * 10 ** decimals());")
+	assert.NotContains(t, contract, "_mint(initialOwner")
+
+	deploy, err := g.GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, deploy, "Allocation:     300000 tokens to 0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+}
+
+func TestTokenConfig_Validate_AllocationsRejected(t *testing.T) {
+	const a, b = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+	tests := []struct {
+		name        string
+		allocations []config.Allocation
+		want        string
+	}{
+		{"mismatched sum", []config.Allocation{{Address: a, Amount: "600000"}, {Address: b, Amount: "300000"}}, "allocations sum to 900000 tokens but the initial supply is 1000000"},
+		{"invalid address", []config.Allocation{{Address: "0x1234", Amount: "1000000"}}, "is not a 0x-prefixed 20-byte hex address"},
+		{"duplicate address", []config.Allocation{{Address: a, Amount: "500000"}, {Address: a, Amount: "500000"}}, "listed more than once"},
+		{"zero amount", []config.Allocation{{Address: a, Amount: "1000000"}, {Address: b, Amount: "0"}}, "amount must be greater than zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Allocations = tt.allocations
+			var verr *config.ValidationError
+			require.ErrorAs(t, cfg.Validate(), &verr)
+			assert.Equal(t, "Allocations", verr.Fields[0].Field)
+			assert.Contains(t, verr.Error(), tt.want)
+		})
+	}
+}
+
+func TestTokenConfig_Validate_InitialHolder(t *testing.T) {
+	cfg := baseConfig()
+	cfg.InitialHolder = "0x1234"
+	var verr *config.ValidationError
+	require.ErrorAs(t, cfg.Validate(), &verr)
+	assert.Equal(t, "InitialHolder", verr.Fields[0].Field)
+
+	cfg = baseConfig()
+	cfg.InitialSupply = ""
+	cfg.InitialHolder = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	require.ErrorAs(t, cfg.Validate(), &verr)
+	assert.Equal(t, "InitialHolder", verr.Fields[0].Field)
+}
+
+func TestGenerator_GenerateContract_NatSpecHeader(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, " * @title TestToken\n")
+	assert.NotContains(t, contract, "@author")
+	assert.NotContains(t, contract, "@notice")
+
+	cfg.Title = "Treasury Token"
+	cfg.Author = "Platform Team <platform@example.com>"
+	cfg.Notice = "Settlement token for internal ledgers."
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, " * @title Treasury Token\n * @author Platform Team <platform@example.com>\n * @notice Settlement token for internal ledgers.\n")
+	assert.Contains(t, contract, "contract TestToken is")
+}
+
+func TestTokenConfig_Validate_NatSpecInjection(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Notice = "ok */ contract Evil {}"
+	cfg.Author = "two\nlines"
+	err := cfg.Validate()
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 2)
+	assert.Equal(t, "Author", verr.Fields[0].Field)
+	assert.Equal(t, "Notice", verr.Fields[1].Field)
+}
+
+func TestGenerator_GenerateContract_PermitIncluded(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Permit = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.Contains(t, contract, "ERC20Permit")
+	assert.Contains(t, contract, "ERC20Permit.sol")
+}
+
+func TestGenerator_GenerateContract_NoInitialSupply(t *testing.T) {
+	cfg := baseConfig()
+	cfg.InitialSupply = ""
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.NotContains(t, contract, "_mint(")
+}
+
+func TestGenerator_GenerateDeployScript_ContainsEssentials(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	script, err := gen.GenerateDeployScript()
+	require.NoError(t, err)
+
+	assert.Contains(t, script, "const { ethers } = require(\"hardhat\")")
+	assert.Contains(t, script, "TestToken")
+	assert.Contains(t, script, "deployer.address")
+	assert.Contains(t, script, "waitForDeployment")
+}
+
+func TestGenerator_GenerateTestSkeleton_ContainsEssentials(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+
+	assert.Contains(t, test, `describe("TestToken"`)
+	assert.Contains(t, test, "Should have correct name and symbol")
+	assert.Contains(t, test, `"TestToken"`)
+	assert.Contains(t, test, `"TST"`)
+	assert.True(t, strings.Contains(test, "deployFixture"))
+}
+
+func TestGenerator_GenerateTestSkeleton_MintableAddsTests(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+
+	assert.Contains(t, test, "Minting")
+	assert.Contains(t, test, "authorized minting")
+	assert.Contains(t, test, "unauthorized minting")
+}
+
+func TestGenerator_GenerateTestSkeleton_CapRevertRequiresCapAndMintable(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxSupply string
+		mintable  bool
+		want      bool
+	}{
+		{"cap and mintable", "10000000", true, true},
+		{"cap only", "10000000", false, false},
+		{"mintable only", "", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.MaxSupply = tt.maxSupply
+			cfg.Mintable = tt.mintable
+			require.NoError(t, cfg.Validate())
+
+			test, err := generator.New(cfg).GenerateTestSkeleton()
+			require.NoError(t, err)
+
+			if tt.want {
+				assert.Contains(t, test, "Should revert when minting beyond the cap")
+				assert.Contains(t, test, "const cap = 10000000000000000000000000n;")
+				assert.Contains(t, test, "ERC20ExceededCap")
+			} else {
+				assert.NotContains(t, test, "ERC20ExceededCap")
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateTestSkeleton_PausableAddsTests(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Pausable = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+
+	assert.Contains(t, test, "Pausable")
+	assert.Contains(t, test, "paused")
+}
+
+func TestGenerator_GenerateCIWorkflow(t *testing.T) {
+	tests := []struct {
+		layout string
+		test   string
+		want   []string
+	}{
+		{"hardhat", "test/TestToken.test.js", []string{"npm ci", "npx hardhat compile", "npx hardhat test test/TestToken.test.js"}},
+		{"flat", "", []string{"npm ci", "npx hardhat compile", "npx hardhat test"}},
+		{"foundry", "test/TestToken.test.js", []string{"npm ci", "forge build", "forge test", "npx hardhat test test/TestToken.test.js"}},
+		{"foundry", "", []string{"npm ci", "forge build", "forge test"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout+"/"+tt.test, func(t *testing.T) {
+			cfg := baseConfig()
+			require.NoError(t, cfg.Validate())
+
+			workflow, err := generator.New(cfg).GenerateCIWorkflow(generator.ProjectFiles{Layout: tt.layout, Test: tt.test})
+			require.NoError(t, err)
+
+			var parsed struct {
+				Name string
+				Jobs map[string]struct {
+					RunsOn string `yaml:"runs-on"`
+					Steps  []struct {
+						Uses string
+						Run  string
+					}
+				}
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(workflow), &parsed))
+			assert.Equal(t, "contracts", parsed.Name)
+			require.Contains(t, parsed.Jobs, "test")
+			job := parsed.Jobs["test"]
+			assert.Equal(t, "ubuntu-latest", job.RunsOn)
+
+			var runs, uses []string
+			for _, s := range job.Steps {
+				if s.Run != "" {
+					runs = append(runs, s.Run)
+				}
+				if s.Uses != "" {
+					uses = append(uses, s.Uses)
+				}
+			}
+			assert.Equal(t, tt.want, runs)
+			if tt.layout == "foundry" {
+				assert.Contains(t, uses, "foundry-rs/foundry-toolchain@v1")
+			} else {
+				assert.NotContains(t, workflow, "forge")
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateEnvExample(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Network = "sepolia"
+	require.NoError(t, cfg.Validate())
+
+	env, err := generator.New(cfg).GenerateEnvExample()
+	require.NoError(t, err)
+
+	var keys []string
+	for _, line := range strings.Split(env, "\n") {
+		if line != "" && !strings.HasPrefix(line, "#") {
+			name, value, ok := strings.Cut(line, "=")
+			require.True(t, ok, "line %q is not KEY=value", line)
+			assert.Empty(t, value, "%s must be a placeholder", name)
+			keys = append(keys, name)
+		}
+	}
+	assert.Equal(t, []string{"DEPLOYER_PRIVATE_KEY", "RPC_URL", "ETHERSCAN_API_KEY"}, keys)
+	assert.Contains(t, env, "echo .env >> .gitignore")
+	assert.Contains(t, env, "# Target network: sepolia (chainId 11155111)")
+}
+
+func TestGenerator_GenerateTypechainConfig(t *testing.T) {
+	cfg := baseConfig()
+	cfg.WithTypechain = true
+	require.NoError(t, cfg.Validate())
+
+	fragment, err := generator.New(cfg).GenerateTypechainConfig()
+	require.NoError(t, err)
+	assert.Contains(t, fragment, `require("@typechain/hardhat");`)
+	assert.Contains(t, fragment, "module.exports = {\n  typechain: {\n    outDir: \"typechain-types\",\n    target: \"ethers-v6\",\n  },\n};\n")
+	assert.Contains(t, fragment, `import { TestToken } from "../typechain-types";`)
+
+	readme, err := generator.New(cfg).GenerateReadme(generator.ProjectFiles{Layout: "hardhat", Contract: "contracts/TestToken.sol", Typechain: "typechain.config.js"})
+	require.NoError(t, err)
+	assert.Contains(t, readme, "## Typed bindings")
+	assert.Contains(t, readme, "| `typechain.config.js` |")
+}
+
+func TestGenerator_GenerateCompilerConfig_EVMVersion(t *testing.T) {
+	cfg := baseConfig()
+	cfg.EVMVersion = config.EVMParis
+	cfg.SolidityVersion = ">=0.8.20 <0.9.0"
+	require.NoError(t, cfg.Validate())
+	gen := generator.New(cfg)
+
+	compiler, err := gen.GenerateCompilerConfig()
+	require.NoError(t, err)
+	assert.Contains(t, compiler, "  solidity: {\n    version: \"0.8.20\",\n    settings: {\n      evmVersion: \"paris\",\n    },\n  },\n")
+
+	foundry, err := gen.GenerateFoundryConfig()
+	require.NoError(t, err)
+	assert.Contains(t, foundry, "[profile.default]\n")
+	assert.Contains(t, foundry, "solc_version = \"0.8.20\"\nevm_version = \"paris\"\n")
+	assert.Contains(t, foundry, `remappings = ["@openzeppelin/=node_modules/@openzeppelin/"]`)
+
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `// Compile with evmVersion "paris"`)
+}
+
+func TestTokenConfig_Validate_EVMVersion(t *testing.T) {
+	cfg := baseConfig()
+	cfg.EVMVersion = "homestead"
+	require.ErrorContains(t, cfg.Validate(), `invalid EVM version "homestead"`)
+
+	for _, v := range config.EVMVersion("").Values() {
+		cfg := baseConfig()
+		cfg.EVMVersion = config.EVMVersion(v)
+		assert.NoError(t, cfg.Validate(), v)
+	}
+}
+
+func TestGenerator_GenerateContract_ReentrancyGuard(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.ReentrancyGuard = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/utils/ReentrancyGuard.sol";`)
+	assert.Contains(t, contract, "contract TestToken is ERC20, Ownable, ReentrancyGuard {")
+	assert.Contains(t, contract, "function mint(address to, uint256 amount) external onlyOwner nonReentrant {")
+
+	cfg.OZVersion = config.OZv4
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/security/ReentrancyGuard.sol";`)
+
+	cfg = baseConfig()
+	cfg.AccessControl = config.AccessRoles
+	cfg.Mintable = true
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.ReentrancyGuard = true
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `import "@openzeppelin/contracts-upgradeable/utils/ReentrancyGuardUpgradeable.sol";`)
+	assert.Contains(t, contract, "__ReentrancyGuard_init();")
+	assert.Contains(t, contract, "function mint(address to, uint256 amount) external onlyRole(MINTER_ROLE) nonReentrant {")
+}
+
+func TestTokenConfig_Validate_ReentrancyGuardNeedsAFunction(t *testing.T) {
+	cfg := baseConfig()
+	cfg.ReentrancyGuard = true
+	require.ErrorContains(t, cfg.Validate(), "--reentrancy-guard has nothing to guard")
+}
+
+func TestGenerator_GenerateContract_VotesPausableUpdateOrder(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Votes = true
+	cfg.Pausable = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	// The later base runs first: Votes must follow Pausable in both
+	// the "is" list and the override specifier.
+	is := contract[strings.Index(contract, "contract TestToken is"):]
+	is = is[:strings.Index(is, "{")]
+	assert.Less(t, strings.Index(is, "ERC20Pausable"), strings.Index(is, "ERC20Votes"))
+
+	override := regexp.MustCompile(`function _update\(address from, address to, uint256 value\)\s+internal\s+override\(([^)]*)\)`).FindStringSubmatch(contract)
+	require.NotNil(t, override)
+	parents := strings.Split(override[1], ", ")
+	assert.Contains(t, parents, "ERC20Pausable")
+	assert.Contains(t, parents, "ERC20Votes")
+	assert.Less(t, slices.Index(parents, "ERC20Pausable"), slices.Index(parents, "ERC20Votes"))
+
+	assert.Equal(t, 1, strings.Count(contract, "super._update("))
+	assert.Contains(t, contract, "ERC20Votes is inherited last")
+}
+
+func TestGenerator_GenerateContract_OZv4Hooks(t *testing.T) {
+	cfg := baseConfig()
+	cfg.OZVersion = config.OZv4
+	cfg.Pausable = true
+	cfg.Locks = true
+	cfg.Votes = true
+	cfg.MaxSupply = "10000000"
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+
+	assert.NotContains(t, contract, "_update(")
+	assert.NotContains(t, contract, "Ownable(initialOwner)")
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/security/Pausable.sol";`)
+	assert.Contains(t, contract, "function _beforeTokenTransfer(address from, address to, uint256 value)")
+	assert.Contains(t, contract, "override(ERC20, ERC20Pausable, ERC20Snapshot)")
+	assert.Contains(t, contract, "uint256 available = balance > locked ? balance - locked : 0;")
+	assert.Contains(t, contract, "super._beforeTokenTransfer(from, to, value);")
+	assert.Contains(t, contract, "function _afterTokenTransfer(address from, address to, uint256 value)")
+	assert.Contains(t, contract, "function _mint(address to, uint256 value)\n        internal\n        override(ERC20, ERC20Capped, ERC20Votes)")
+	assert.Contains(t, contract, "function _burn(address from, uint256 value)\n        internal\n        override(ERC20, ERC20Votes)")
+	assert.Contains(t, contract, "_transferOwnership(initialOwner);")
+	assert.NotContains(t, contract, "function nonces(")
+
+	test, err := generator.New(cfg).GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, `.to.be.revertedWith("ERC20Pausable: token transfer while paused");`)
+	assert.Contains(t, test, `.to.be.revertedWith("ERC20: transfer amount exceeds balance");`)
+	assert.NotContains(t, test, "EnforcedPause")
+
+	cfg.Upgradeable = config.UpgradeUUPS
+	upgradeable, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, upgradeable, "__Ownable_init();\n        _transferOwnership(initialOwner);")
+	assert.Contains(t, upgradeable, "override(ERC20Upgradeable, ERC20PausableUpgradeable, ERC20SnapshotUpgradeable)")
+	assert.NotContains(t, upgradeable, "_update(")
+}
+
+func TestGenerator_GenerateInvariantTest(t *testing.T) {
+	files := generator.ProjectFiles{Layout: "foundry", Contract: "src/TestToken.sol", Invariants: "test/TestTokenInvariants.t.sol"}
+
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.Burnable = true
+	cfg.MaxSupply = "5000000"
+	require.NoError(t, cfg.Validate())
+	src, err := generator.New(cfg).GenerateInvariantTest(files)
+	require.NoError(t, err)
+	assert.Contains(t, src, `import {TestToken} from "../src/TestToken.sol";`)
+	assert.Contains(t, src, "token = new TestToken(address(this));")
+	assert.Contains(t, src, "function invariant_totalSupplyWithinCap() public {\n        assertLe(token.totalSupply(), token.cap());")
+	assert.Contains(t, src, "function invariant_balancesSumToTotalSupply() public {")
+	assert.Contains(t, src, "amount = bound(amount, 0, token.cap());")
+	assert.Contains(t, src, "token.burn(amount);")
+	assert.NotContains(t, src, "genesisSupply")
+
+	// Without a mint path the supply is bounded by the genesis mint instead
+	cfg = baseConfig()
+	cfg.AccessControl = config.AccessNone
+	cfg.ExtraConstructorParams = []string{"address treasury", "bytes4 tag"}
+	require.NoError(t, cfg.Validate())
+	src, err = generator.New(cfg).GenerateInvariantTest(files)
+	require.NoError(t, err)
+	assert.Contains(t, src, "token = new TestToken(address(0), bytes4(0));")
+	assert.Contains(t, src, "function invariant_totalSupplyWithinGenesis() public {")
+	assert.NotContains(t, src, "invariant_totalSupplyWithinCap")
+	assert.NotContains(t, src, "function mint(")
+}
+
+func TestGenerator_GenerateReadme_PinsOZVersion(t *testing.T) {
+	for _, tt := range []struct {
+		oz   config.OZVersion
+		want string
+	}{
+		{config.OZv4, "@openzeppelin/contracts@^4.9.0"},
+		{config.OZv5, "@openzeppelin/contracts@^5.0.0"},
+	} {
+		t.Run("v"+string(tt.oz), func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.OZVersion = tt.oz
+			require.NoError(t, cfg.Validate())
+
+			readme, err := generator.New(cfg).GenerateReadme(generator.ProjectFiles{Layout: "hardhat", Contract: "contracts/TestToken.sol"})
+			require.NoError(t, err)
+			assert.Contains(t, readme, "npm install "+tt.want+"\n")
+
+			cfg.Upgradeable = config.UpgradeUUPS
+			readme, err = generator.New(cfg).GenerateReadme(generator.ProjectFiles{Layout: "hardhat", Contract: "contracts/TestToken.sol"})
+			require.NoError(t, err)
+			assert.Contains(t, readme, tt.want+" @openzeppelin/contracts-upgradeable@"+cfg.OZVersionString())
+		})
+	}
+}
+
+func TestTokenConfig_Validate_TypechainRequiresEthersTests(t *testing.T) {
+	cfg := baseConfig()
+	cfg.WithTypechain = true
+	cfg.TestStyle = config.TestStyleViemTS
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--with-typechain targets ethers")
+}
+
+func TestGenerator_GenerateContract_CustomErrorsOrRequireStrings(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Airdrop = true
+	cfg.Locks = true
+	cfg.Mintable = true
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "1"
+	cfg.EmissionStart = 1700000000
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	for _, want := range []string{
+		"error EmissionScheduleExceeded(uint256 requested, uint256 available);",
+		"error BatchLengthMismatch(uint256 recipients, uint256 amounts);",
+		"error LockReleaseInPast(uint64 releaseTime);",
+		"revert EmissionScheduleExceeded(amount, available);",
+		"revert LockReleaseInPast(releaseTime);",
+	} {
+		assert.Contains(t, contract, want)
+	}
+	assert.NotContains(t, contract, "require(")
+
+	cfg.RequireStrings = true
+	gen = generator.New(cfg)
+	contract, err = gen.GenerateContract()
+	require.NoError(t, err)
+	for _, want := range []string{
+		`require(amount <= available, "TestToken: amount exceeds emission schedule");`,
+		`require(recipients.length == amounts.length, "TestToken: array length mismatch");`,
+		`require(releaseTime > block.timestamp, "TestToken: release time is in the past");`,
+		`require(value <= available, "TestToken: transfer exceeds unlocked balance");`,
+	} {
+		assert.Contains(t, contract, want)
+	}
+	assert.NotContains(t, contract, "error ")
+	assert.NotContains(t, contract, "revert ")
+
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, `.to.be.revertedWith("TestToken: array length mismatch");`)
+	assert.NotContains(t, test, `revertedWithCustomError(token, "BatchLengthMismatch")`)
+}
+
+func TestGenerator_GenerateContract_GuardStyle(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Locks = true
+	cfg.Mintable = true
+	cfg.MintSchedule = config.MintScheduleLinear
+	cfg.EmissionRatePerSecond = "1"
+	cfg.EmissionStart = 1700000000
+	cfg.SolidityVersion = "^0.8.27"
+
+	cfg.GuardStyle = config.GuardRequire
+	require.NoError(t, cfg.Validate())
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "require(amount <= available, EmissionScheduleExceeded(amount, available));")
+	assert.Contains(t, contract, "require(value <= available, LockedBalanceExceeded(from, available, value));")
+	assert.NotContains(t, contract, "revert ")
+
+	cfg.RequireStrings = true
+	cfg.GuardStyle = config.GuardIfRevert
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "if (amount > available) {\n            revert(\"TestToken: amount exceeds emission schedule\");")
+	assert.Contains(t, contract, `revert("TestToken: transfer exceeds unlocked balance");`)
+	assert.NotContains(t, contract, "require(")
+}
+
+func TestTokenConfig_Validate_GuardStyle(t *testing.T) {
+	cfg := baseConfig()
+	cfg.GuardStyle = "assert"
+	require.ErrorContains(t, cfg.Validate(), `invalid guard style "assert"`)
+
+	cfg = baseConfig()
+	cfg.GuardStyle = config.GuardRequire
+	require.ErrorContains(t, cfg.Validate(), "require with custom errors needs solc 0.8.27")
+
+	cfg.RequireStrings = true
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestGenerator_GenerateTestSkeleton_PausedVotesRequiresBoth(t *testing.T) {
+	tests := []struct {
+		name     string
+		pausable bool
+		votes    bool
+		want     bool
+	}{
+		{"pausable and votes", true, true, true},
+		{"pausable only", true, false, false},
+		{"votes only", false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Pausable = tt.pausable
+			cfg.Votes = tt.votes
+			require.NoError(t, cfg.Validate())
+
+			test, err := generator.New(cfg).GenerateTestSkeleton()
+			require.NoError(t, err)
+
+			const name = "Should freeze voting power while paused and resume after unpause"
+			if tt.want {
+				assert.Contains(t, test, name)
+				assert.Contains(t, test, "expect(await token.getVotes(owner.address)).to.equal(votes - amount);")
+			} else {
+				assert.NotContains(t, test, name)
+			}
+		})
+	}
+}
+
+func TestGenerator_CappedBurnableMintable_RefillsCap(t *testing.T) {
+	tests := []struct {
+		name      string
+		mintable  bool
+		burnable  bool
+		maxSupply string
+		want      bool
+	}{
+		{"mintable, burnable and capped", true, true, "10000000", true},
+		{"not burnable", true, false, "10000000", false},
+		{"not mintable", false, true, "10000000", false},
+		{"uncapped", true, true, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Mintable = tt.mintable
+			cfg.Burnable = tt.burnable
+			cfg.MaxSupply = tt.maxSupply
+			require.NoError(t, cfg.Validate())
+
+			gen := generator.New(cfg)
+			contract, err := gen.GenerateContract()
+			require.NoError(t, err)
+			test, err := gen.GenerateTestSkeleton()
+			require.NoError(t, err)
+
+			const note = "burned tokens free room that mint() can refill"
+			const name = "Should let burned tokens be re-minted up to the cap"
+			if tt.want {
+				assert.Contains(t, contract, note)
+				assert.Contains(t, test, name)
+				assert.Contains(t, test, "await token.burn(amount);\n      // The cap bounds totalSupply(), so the burned amount can be minted again\n      await token.mint(addr1.address, amount);")
+			} else {
+				assert.NotContains(t, contract, note)
+				assert.NotContains(t, test, name)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateContract_UUPSUpgradeable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Upgradeable = config.UpgradeUUPS
+	cfg.Mintable = true
+	cfg.Pausable = true
 	require.NoError(t, cfg.Validate())
 
 	gen := generator.New(cfg)
 	contract, err := gen.GenerateContract()
 	require.NoError(t, err)
 
-	assert.Contains(t, contract, "ERC20Permit")
-	assert.Contains(t, contract, "ERC20Permit.sol")
+	assert.Contains(t, contract, "initialize(address initialOwner) public initializer")
+	assert.Contains(t, contract, `import "@openzeppelin/contracts-upgradeable/token/ERC20/ERC20Upgradeable.sol"`)
+	assert.Contains(t, contract, "@openzeppelin/contracts-upgradeable/proxy/utils/UUPSUpgradeable.sol")
+	assert.Contains(t, contract, "is Initializable, ERC20Upgradeable, ERC20PausableUpgradeable, OwnableUpgradeable, UUPSUpgradeable")
+	assert.Contains(t, contract, "_disableInitializers()")
+	assert.Contains(t, contract, "function _authorizeUpgrade(address newImplementation) internal override onlyOwner")
+	assert.NotContains(t, contract, `import "@openzeppelin/contracts/`)
+	assert.NotContains(t, contract, "Ownable(initialOwner)")
 }
 
-func TestGenerator_GenerateContract_NoInitialSupply(t *testing.T) {
+func TestGenerator_GenerateContract_TransparentUpgradeable(t *testing.T) {
 	cfg := baseConfig()
-	cfg.InitialSupply = ""
+	cfg.Upgradeable = config.UpgradeTransparent
+	cfg.AccessControl = config.AccessRoles
 	require.NoError(t, cfg.Validate())
 
 	gen := generator.New(cfg)
 	contract, err := gen.GenerateContract()
 	require.NoError(t, err)
 
-	assert.NotContains(t, contract, "_mint(")
+	assert.Contains(t, contract, "initialize(address defaultAdmin) public initializer")
+	assert.Contains(t, contract, "__AccessControl_init()")
+	assert.Contains(t, contract, "@openzeppelin/contracts-upgradeable/access/AccessControlUpgradeable.sol")
+	assert.NotContains(t, contract, "_authorizeUpgrade")
+	assert.NotContains(t, contract, "UUPSUpgradeable")
 }
 
-func TestGenerator_GenerateDeployScript_ContainsEssentials(t *testing.T) {
+func TestGenerator_GenerateDeployScript_Upgradeable(t *testing.T) {
 	cfg := baseConfig()
+	cfg.Upgradeable = config.UpgradeUUPS
 	require.NoError(t, cfg.Validate())
 
 	gen := generator.New(cfg)
 	script, err := gen.GenerateDeployScript()
 	require.NoError(t, err)
 
-	assert.Contains(t, script, "const { ethers } = require(\"hardhat\")")
-	assert.Contains(t, script, "TestToken")
-	assert.Contains(t, script, "deployer.address")
-	assert.Contains(t, script, "waitForDeployment")
+	assert.Contains(t, script, `const { ethers, upgrades } = require("hardhat")`)
+	assert.Contains(t, script, "upgrades.deployProxy(")
+	assert.Contains(t, script, `kind: "uups"`)
 }
 
-func TestGenerator_GenerateTestSkeleton_ContainsEssentials(t *testing.T) {
+func TestGenerator_SameSeedProducesIdenticalOutput(t *testing.T) {
+	render := func(seed int64) string {
+		cfg := baseConfig()
+		cfg.Mintable = true
+		require.NoError(t, cfg.Validate())
+
+		gen := generator.NewWithSeed(cfg, seed)
+		contract, err := gen.GenerateContract()
+		require.NoError(t, err)
+		deploy, err := gen.GenerateDeployScript()
+		require.NoError(t, err)
+		test, err := gen.GenerateTestSkeleton()
+		require.NoError(t, err)
+		return contract + deploy + test
+	}
+
+	assert.Equal(t, render(42), render(42))
+	assert.NotEqual(t, render(42), render(43), "placeholder values should depend on the seed")
+}
+
+func TestGenerator_GenerateDeployScript_Network(t *testing.T) {
 	cfg := baseConfig()
+	cfg.Network = "polygon"
 	require.NoError(t, cfg.Validate())
 
-	gen := generator.New(cfg)
-	test, err := gen.GenerateTestSkeleton()
+	script, err := generator.New(cfg).GenerateDeployScript()
 	require.NoError(t, err)
 
-	assert.Contains(t, test, `describe("TestToken"`)
-	assert.Contains(t, test, "Should have correct name and symbol")
-	assert.Contains(t, test, `"TestToken"`)
-	assert.Contains(t, test, `"TST"`)
-	assert.True(t, strings.Contains(test, "deployFixture"))
+	assert.Contains(t, script, "--network polygon")
+	assert.Contains(t, script, "chainId !== 137n")
+	assert.Contains(t, script, "getFeeData()")
+	assert.Contains(t, script, `console.log("  npx hardhat verify --network polygon", address, deployer.address);`)
 }
 
-func TestGenerator_GenerateTestSkeleton_MintableAddsTests(t *testing.T) {
+func TestGenerator_GenerateDeployScript_CustomNetworkSkipsChainCheck(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Network = "custom"
+	cfg.AccessControl = config.AccessNone
+	require.NoError(t, cfg.Validate())
+
+	script, err := generator.New(cfg).GenerateDeployScript()
+	require.NoError(t, err)
+
+	assert.NotContains(t, script, "throw new Error(\"Expected")
+	assert.Contains(t, script, `console.log("  npx hardhat verify --network custom", address);`)
+	assert.Contains(t, script, "constructorArguments: [],")
+}
+
+func TestGenerator_GenerateDeployScript_NoNetworkByDefault(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+
+	script, err := generator.New(cfg).GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, "--network <network>")
+	assert.NotContains(t, script, "npx hardhat verify")
+}
+
+func TestTokenConfig_Validate_UnknownNetwork(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Network = "goerli"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown network "goerli"`)
+}
+
+func TestGenerator_GenerateTestSkeleton_Viem(t *testing.T) {
 	cfg := baseConfig()
 	cfg.Mintable = true
+	cfg.Pausable = true
+	cfg.TestStyle = config.TestStyleViemTS
+	require.NoError(t, cfg.Validate())
+
+	test, err := generator.New(cfg).GenerateTestSkeleton()
+	require.NoError(t, err)
+
+	assert.Contains(t, test, `import { getAddress, getContract, parseUnits, zeroAddress } from "viem";`)
+	assert.Contains(t, test, "const [walletClient, otherClient] = await hre.viem.getWalletClients();")
+	assert.Contains(t, test, "client: { public: publicClient, wallet: walletClient }")
+	assert.Contains(t, test, `hre.viem.deployContract("TestToken", [walletClient.account.address])`)
+	assert.Contains(t, test, "token.write.mint([other, amount])")
+	assert.Contains(t, test, `rejectedWith("EnforcedPause")`)
+	assert.NotContains(t, test, "ethers")
+	assert.Equal(t, "TestToken.test.ts", cfg.TestFileName())
+}
+
+func TestTokenConfig_Validate_ViemRejectsUpgradeable(t *testing.T) {
+	cfg := baseConfig()
+	cfg.TestStyle = config.TestStyleViemTS
+	cfg.Upgradeable = config.UpgradeTransparent
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "viem-ts tests do not support upgradeable tokens")
+}
+
+func TestGenerator_GenerateContract_SnapshotOnDeploy(t *testing.T) {
+	cfg := baseConfig()
+	cfg.OZVersion = config.OZv4
+	cfg.Snapshot = true
+	cfg.SnapshotOnDeploy = true
 	require.NoError(t, cfg.Validate())
 
 	gen := generator.New(cfg)
-	test, err := gen.GenerateTestSkeleton()
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "✓ Genesis Snapshot")
+	// The snapshot follows the genesis mint so it records those balances.
+	assert.Contains(t, contract, `        _mint(initialOwner, 1_000_000 * 10 ** decimals());
+        // Snapshot 1 records the genesis balances (e.g. for airdrop
+        // eligibility); query them with balanceOfAt(account, 1).
+        _snapshot();
+    }`)
+
+	deploy, err := gen.GenerateDeployScript()
 	require.NoError(t, err)
+	assert.Contains(t, deploy, `event?.name === "Snapshot"`)
+	assert.Contains(t, deploy, `console.log("   Snapshot ID:    ", snapshot.args.id.toString(), "(genesis balances)");`)
 
-	assert.Contains(t, test, "Minting")
-	assert.Contains(t, test, "authorized minting")
-	assert.Contains(t, test, "unauthorized minting")
+	cfg.SnapshotOnDeploy = false
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "Snapshot 1 records")
 }
 
-func TestGenerator_GenerateTestSkeleton_PausableAddsTests(t *testing.T) {
+func TestTokenConfig_Validate_SnapshotOnDeployRequiresSnapshot(t *testing.T) {
 	cfg := baseConfig()
-	cfg.Pausable = true
+	cfg.SnapshotOnDeploy = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot on deploy requires the snapshot feature")
+}
+
+func TestGenerator_GenerateContract_PermitWithCustomDecimals(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Permit = true
+	cfg.Decimals = 6
+	cfg.MaxSupply = "5000000"
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "contract TestToken is ERC20, ERC20Capped, ERC20Permit, Ownable {")
+
+	// Base constructors are listed in inheritance order.
+	assert.Contains(t, contract, `    constructor(address initialOwner)
+        ERC20("TestToken", "TST")
+        ERC20Capped(5_000_000_000_000)
+        ERC20Permit("TestToken")
+        Ownable(initialOwner)
+    {`)
+
+	// The domain separator comes from the name alone; decimals stays a
+	// plain override that never reaches EIP712.
+	assert.Contains(t, contract, "function decimals() public pure override returns (uint8) {\n        return 6;")
+	assert.Contains(t, contract, "so permit signatures are unaffected.")
+	assert.NotContains(t, contract, "EIP712(")
+	assert.NotContains(t, contract, "ERC20Permit(decimals")
+}
+
+func TestGenerator_ExtraConstructorParams(t *testing.T) {
+	cfg := baseConfig()
+	cfg.ExtraConstructorParams = []string{"address treasury", "uint16 feeBps"}
 	require.NoError(t, cfg.Validate())
 
 	gen := generator.New(cfg)
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "address public immutable treasury;")
+	assert.Contains(t, contract, "uint16 public immutable feeBps;")
+	assert.Contains(t, contract, "constructor(address initialOwner, address treasury_, uint16 feeBps_)")
+	assert.Contains(t, contract, "treasury = treasury_;")
+	assert.Contains(t, contract, "feeBps = feeBps_;")
+
+	script, err := gen.GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, `const treasury = "0x0000000000000000000000000000000000000000"; // address`)
+	assert.Contains(t, script, "const feeBps = 0n; // uint16")
+	assert.Contains(t, script, ".deploy(deployer.address, treasury, feeBps);")
+	assert.Contains(t, script, "constructorArguments: [deployer.address, treasury, feeBps],")
+
 	test, err := gen.GenerateTestSkeleton()
 	require.NoError(t, err)
+	assert.Contains(t, test, `.deploy(owner.address, "0x0000000000000000000000000000000000000000", 0n);`)
+}
 
-	assert.Contains(t, test, "Pausable")
-	assert.Contains(t, test, "paused")
+func TestTokenConfig_Validate_ExtraConstructorParams(t *testing.T) {
+	tests := []struct {
+		param   string
+		wantErr string
+	}{
+		{"treasury", "must be \"<type> <name>\""},
+		{"string memo", "must be \"<type> <name>\""},
+		{"uint7 odd", "multiple of 8"},
+		{"bytes33 blob", "between 1 and 32"},
+		{"address initialOwner", "is reserved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.ExtraConstructorParams = []string{tt.param}
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	cfg := baseConfig()
+	cfg.ExtraConstructorParams = []string{"address treasury", "bool treasury"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate constructor param "treasury"`)
+}
+
+func TestGenerator_GenerateContract_ExposeParams(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MaxSupply = "5000000"
+	cfg.ExtraConstructorParams = []string{"address feeRecipient"}
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "public immutable maxSupply")
+
+	cfg.ExposeParams = true
+	require.NoError(t, cfg.Validate())
+	gen := generator.New(cfg)
+	contract, err = gen.GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "address public immutable feeRecipient;")
+	assert.Contains(t, contract, "uint256 public immutable initialSupply;")
+	assert.Contains(t, contract, "uint256 public immutable maxSupply;")
+	assert.Contains(t, contract, "initialSupply = 1_000_000 * 10 ** decimals();")
+	assert.Contains(t, contract, "maxSupply = 5_000_000_000_000_000_000_000_000;")
+
+	test, err := gen.GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, "expect(await token.maxSupply()).to.equal(await token.cap());")
+
+	// Nothing to record without a supply or a cap
+	cfg.InitialSupply, cfg.MaxSupply = "", ""
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "--expose-params")
+
+	cfg = baseConfig()
+	cfg.ExposeParams = true
+	cfg.ExtraConstructorParams = []string{"uint256 maxSupply"}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `constructor param "maxSupply" collides`)
+
+	cfg = baseConfig()
+	cfg.ExposeParams = true
+	cfg.Upgradeable = config.UpgradeUUPS
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--expose-params is not supported for upgradeable tokens")
+}
+
+func TestGenerator_GenerateContract_EmitCapReached(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.MaxSupply = "5000000"
+	cfg.EmitCapReached = true
+	require.NoError(t, cfg.Validate())
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, "    event CapReached();")
+
+	mintFn := contract[strings.Index(contract, "function mint("):]
+	mintFn = mintFn[:strings.Index(mintFn, "\n    }")]
+	assert.Contains(t, mintFn, "_mint(to, amount);\n        if (totalSupply() == cap()) {\n            emit CapReached();\n        }")
+
+	test, err := generator.New(cfg).GenerateTestSkeleton()
+	require.NoError(t, err)
+	assert.Contains(t, test, `.to.emit(token, "CapReached")`)
+
+	cfg = baseConfig()
+	cfg.Mintable = true
+	cfg.MaxSupply = "5000000"
+	require.NoError(t, cfg.Validate())
+	contract, err = generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.NotContains(t, contract, "CapReached")
+}
+
+func TestTokenConfig_Validate_EmitCapReachedNeedsMintableCap(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	cfg.EmitCapReached = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "emit cap reached requires --mintable and --max-supply")
+
+	cfg = baseConfig()
+	cfg.MaxSupply = "5000000"
+	cfg.EmitCapReached = true
+	require.Error(t, cfg.Validate())
+}
+
+func governorConfig() *config.TokenConfig {
+	cfg := baseConfig()
+	cfg.Votes = true
+	cfg.WithGovernor = true
+	cfg.GovernorVotingDelay = config.DefaultVotingDelay
+	cfg.GovernorVotingPeriod = config.DefaultVotingPeriod
+	cfg.GovernorQuorumPercent = 10
+	return cfg
+}
+
+func TestGenerator_GenerateGovernor(t *testing.T) {
+	cfg := governorConfig()
+	require.NoError(t, cfg.Validate())
+
+	governor, err := generator.New(cfg).GenerateGovernor()
+	require.NoError(t, err)
+	assert.Contains(t, governor, "contract TestTokenGovernor is Governor, GovernorSettings, GovernorCountingSimple, GovernorVotes, GovernorVotesQuorumFraction {")
+	assert.Contains(t, governor, `import "@openzeppelin/contracts/governance/extensions/GovernorVotesQuorumFraction.sol";`)
+	assert.Contains(t, governor, "On-chain governance for TestToken (TST)")
+	assert.Contains(t, governor, "GovernorSettings(7_200, 50_400, 0)")
+	assert.Contains(t, governor, "GovernorVotesQuorumFraction(10)")
+	assert.Contains(t, governor, "Quorum:        10% of the total supply")
+	assert.Contains(t, governor, "function quorum(uint256 timepoint) public view override(Governor, GovernorVotesQuorumFraction)")
+	assert.Contains(t, governor, "Voting period: 50400 blocks")
+
+	cfg = governorConfig()
+	cfg.OZVersion = config.OZv4
+	cfg.ClockMode = config.ClockTimestamp
+	cfg.GovernorVotingPeriod = 604800
+	require.NoError(t, cfg.Validate())
+	governor, err = generator.New(cfg).GenerateGovernor()
+	require.NoError(t, err)
+	assert.Contains(t, governor, "function votingDelay() public view override(IGovernor, GovernorSettings)")
+	assert.Contains(t, governor, "function proposalThreshold() public view override(Governor, GovernorSettings)")
+	assert.Contains(t, governor, "Voting period: 604800 seconds")
+}
+
+func TestTokenConfig_Validate_Governor(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*config.TokenConfig)
+		wantErr string
+	}{
+		{"without votes", func(c *config.TokenConfig) { c.Votes = false }, "a governor requires --votes"},
+		{"negative delay", func(c *config.TokenConfig) { c.GovernorVotingDelay = -1 }, "voting delay -1 must be between 0 and"},
+		{"zero period", func(c *config.TokenConfig) { c.GovernorVotingPeriod = 0 }, "voting period 0 must be between 1 and 4294967295"},
+		{"period overflows uint32", func(c *config.TokenConfig) { c.GovernorVotingPeriod = 1 << 32 }, "must be between 1 and 4294967295"},
+		{"zero quorum", func(c *config.TokenConfig) { c.GovernorQuorumPercent = 0 }, "quorum 0% must be between 1 and 100"},
+		{"quorum above 100", func(c *config.TokenConfig) { c.GovernorQuorumPercent = 101 }, "quorum 101% must be between 1 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := governorConfig()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	cfg := governorConfig()
+	cfg.GovernorVotingDelay = 0
+	assert.NoError(t, cfg.Validate(), "a zero voting delay is allowed")
+}
+
+func TestGenerator_GenerateDeployScript_NetworkConfigs(t *testing.T) {
+	cfg := baseConfig()
+	cfg.ExtraConstructorParams = []string{"address treasury"}
+	cfg.NetworkConfigs = map[string]config.NetworkParams{
+		"sepolia": {Treasury: "0x3333333333333333333333333333333333333333"},
+		"mainnet": {
+			Owner:    "0x1111111111111111111111111111111111111111",
+			Treasury: "0x2222222222222222222222222222222222222222",
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	script, err := generator.New(cfg).GenerateDeployScript()
+	require.NoError(t, err)
+	assert.Contains(t, script, `const { ethers, network } = require("hardhat");`)
+	assert.Contains(t, script, `"mainnet": { owner: "0x1111111111111111111111111111111111111111", treasury: "0x2222222222222222222222222222222222222222" },`)
+	assert.Contains(t, script, `"sepolia": { treasury: "0x3333333333333333333333333333333333333333" },`)
+	assert.Less(t, strings.Index(script, `"mainnet"`), strings.Index(script, `"sepolia"`), "networks should be sorted")
+	assert.Contains(t, script, "const params = networkParams[network.name];")
+	assert.Contains(t, script, "const owner = params.owner ?? deployer.address;")
+	assert.Contains(t, script, "const treasury = params.treasury; // address")
+	assert.Contains(t, script, ".deploy(owner, treasury);")
+	assert.Contains(t, script, "constructorArguments: [owner, treasury],")
+}
+
+func TestTokenConfig_Validate_NetworkConfigs(t *testing.T) {
+	owner := "0x1111111111111111111111111111111111111111"
+	tests := []struct {
+		name    string
+		modify  func(*config.TokenConfig)
+		params  config.NetworkParams
+		wantErr string
+	}{
+		{"bad owner", nil, config.NetworkParams{Owner: "0x123"}, "network mainnet: owner:"},
+		{"owner without access", func(c *config.TokenConfig) { c.AccessControl = config.AccessNone }, config.NetworkParams{Owner: owner}, "owner requires access control"},
+		{"treasury without ctor param", nil, config.NetworkParams{Treasury: owner}, `treasury requires --ctor-param "address treasury"`},
+		{"missing treasury", func(c *config.TokenConfig) { c.ExtraConstructorParams = []string{"address treasury"} }, config.NetworkParams{Owner: owner}, "treasury is required"},
+		{"with --network", func(c *config.TokenConfig) { c.Network = "mainnet" }, config.NetworkParams{Owner: owner}, "networks conflict with --network"},
+		{"hardhat-deploy", func(c *config.TokenConfig) { c.DeployStyle = config.DeployStyleHardhatDeploy }, config.NetworkParams{Owner: owner}, "only supported by the ethers deploy script"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+			cfg.NetworkConfigs = map[string]config.NetworkParams{"mainnet": tt.params}
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_ExtraImportsAndParents(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Burnable = true
+	cfg.ExtraImports = []string{
+		"@openzeppelin/contracts/token/ERC20/extensions/ERC20FlashMint.sol",
+		"@openzeppelin/contracts/token/ERC20/extensions/ERC20Burnable.sol", // already imported
+	}
+	cfg.ExtraParents = []string{"ERC20FlashMint"}
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, "@openzeppelin/contracts/token/ERC20/extensions/ERC20FlashMint.sol", cfg.ImportPaths()[len(cfg.ImportPaths())-1])
+	assert.Equal(t, 1, strings.Count(strings.Join(cfg.ImportPaths(), "\n"), "ERC20Burnable.sol"))
+
+	contract, err := generator.New(cfg).GenerateContract()
+	require.NoError(t, err)
+	assert.Contains(t, contract, `import "@openzeppelin/contracts/token/ERC20/extensions/ERC20FlashMint.sol";`)
+	assert.Contains(t, contract, "contract TestToken is ERC20, ERC20Burnable, Ownable, ERC20FlashMint {")
+
+	require.Len(t, cfg.Warnings(), 1)
+	assert.Contains(t, cfg.Warnings()[0], "extra parents (ERC20FlashMint) are inherited as-is")
+}
+
+func TestTokenConfig_Validate_ExtraImportsAndParents(t *testing.T) {
+	tests := []struct {
+		name    string
+		imports []string
+		parents []string
+		wantErr string
+	}{
+		{"not a sol path", []string{"contracts/Taxed.js"}, nil, "is not a Solidity import path"},
+		{"quote in path", []string{`Taxed.sol"; import "x.sol`}, nil, "is not a Solidity import path"},
+		{"bad identifier", nil, []string{"1Taxed"}, "is not a valid Solidity identifier"},
+		{"already inherited", nil, []string{"Ownable"}, "Ownable is already inherited"},
+		{"duplicate", nil, []string{"Taxed", "Taxed"}, `duplicate extra parent "Taxed"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.ExtraImports = tt.imports
+			cfg.ExtraParents = tt.parents
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_GenerateSingleFile_BundlesContractAndDeploy(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Mintable = true
+	require.NoError(t, cfg.Validate())
+
+	gen := generator.New(cfg)
+	single, err := gen.GenerateSingleFile()
+	require.NoError(t, err)
+
+	contract, err := gen.GenerateContract()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(single, contract), "bundle should start with the full contract")
+	assert.Contains(t, single, "Deployment snippet for TestToken (TST)")
+	assert.Contains(t, single, `// const { ethers } = require("hardhat");`)
+	assert.Contains(t, single, "//   const token = await TestToken.deploy(deployer.address);")
+
+	// Everything after the contract must be commented out so the file compiles.
+	for _, line := range strings.Split(strings.TrimPrefix(single, contract), "\n") {
+		if strings.TrimSpace(line) != "" {
+			assert.True(t, strings.HasPrefix(line, "//"), "uncommented line in snippet: %q", line)
+		}
+	}
 }
 
 // ─── InheritanceList Tests ────────────────────────────────────────────────────
@@ -388,4 +3093,25 @@ func TestTokenConfig_InheritanceList_CappedFirst(t *testing.T) {
 
 	list := cfg.InheritanceList()
 	assert.Equal(t, "ERC20Capped", list[0], "ERC20Capped should be first in inheritance")
-}
\ No newline at end of file
+}
+
+func TestTokenConfig_Validate_WhitespaceStyle(t *testing.T) {
+	cfg := baseConfig()
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, config.LineEndingLF, cfg.EOL)
+
+	for _, indent := range []string{"tabs", "2", "8"} {
+		cfg := baseConfig()
+		cfg.Indent = indent
+		assert.NoError(t, cfg.Validate(), indent)
+	}
+	for _, indent := range []string{"0", "9", "tab", " 2"} {
+		cfg := baseConfig()
+		cfg.Indent = indent
+		assert.Error(t, cfg.Validate(), indent)
+	}
+
+	cfg = baseConfig()
+	cfg.EOL = "cr"
+	assert.Error(t, cfg.Validate())
+}
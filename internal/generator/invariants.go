@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+)
+
+// GenerateInvariantTest renders a Foundry invariant test for the token at
+// files.Contract, written to files.Invariants: a handler that deploys the
+// token and fuzzes transfers (plus mints and burns when the token has them)
+// between a fixed set of holders, and invariants that the supply stays
+// within the cap and equals the holders' balances.
+func (g *Generator) GenerateInvariantTest(files ProjectFiles) (string, error) {
+	return g.GenerateInvariantTestCtx(context.Background(), files)
+}
+
+// GenerateInvariantTestCtx is GenerateInvariantTest, aborted once ctx is
+// done.
+func (g *Generator) GenerateInvariantTestCtx(ctx context.Context, files ProjectFiles) (string, error) {
+	var args []string
+	if g.cfg.HasAccessControl() {
+		args = append(args, "address(this)")
+	}
+	for _, p := range g.cfg.CtorParams() {
+		args = append(args, p.SolidityZero())
+	}
+	return g.render(ctx, "invariants.t.sol.tmpl", struct {
+		*config.TokenConfig
+		Import     string
+		DeployArgs string
+	}{g.cfg, relativeImport(files.Invariants, files.Contract), strings.Join(args, ", ")})
+}
+
+// relativeImport returns the Solidity import path of target as seen from
+// the file at from; both are root-relative and slash-separated.
+func relativeImport(from, target string) string {
+	dir := strings.Split(path.Dir(from), "/")
+	if dir[0] == "." {
+		dir = nil
+	}
+	parts := strings.Split(target, "/")
+	for len(dir) > 0 && len(parts) > 1 && dir[0] == parts[0] {
+		dir, parts = dir[1:], parts[1:]
+	}
+	if len(dir) == 0 {
+		return "./" + strings.Join(parts, "/")
+	}
+	return strings.Repeat("../", len(dir)) + strings.Join(parts, "/")
+}
@@ -0,0 +1,64 @@
+package generator
+
+import "strings"
+
+// minify strips comments and blank lines from Solidity source. String
+// literals are copied verbatim, so a "//" inside quotes survives. Line
+// comments the toolchain reads are kept: the SPDX license identifier,
+// @custom:oz-upgrades annotations checked by the upgrades plugin, and the
+// --provenance header.
+func minify(src string) string {
+	var out strings.Builder
+	n := len(src)
+	for i := 0; i < n; {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < n && src[j] != c && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < n {
+				j++
+			}
+			out.WriteString(src[i:min(j, n)])
+			i = min(j, n)
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = n - i
+			}
+			if text := src[i : i+end]; keepComment(text) {
+				out.WriteString(text)
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				i = n
+			} else {
+				i += end + 4
+			}
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	var lines []string
+	for _, l := range strings.Split(out.String(), "\n") {
+		if l = strings.TrimRight(l, " \t"); strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func keepComment(c string) bool {
+	return strings.HasPrefix(c, "// SPDX-License-Identifier:") ||
+		strings.HasPrefix(c, "// provenance:") ||
+		strings.Contains(c, "@custom:oz-upgrades")
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinify(t *testing.T) {
+	src := `// SPDX-License-Identifier: MIT
+// Generated by erc20gen
+pragma solidity ^0.8.24;
+
+/**
+ * @title Token
+ */
+contract Token {
+    string public constant URL = "https://example.com//path"; // trailing comment
+    string public constant Q = 'it\'s // not a comment';
+
+
+    /// @custom:oz-upgrades-unsafe-allow constructor
+    constructor() /* inline */ {}
+}
+`
+	want := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+contract Token {
+    string public constant URL = "https://example.com//path";
+    string public constant Q = 'it\'s // not a comment';
+    /// @custom:oz-upgrades-unsafe-allow constructor
+    constructor()  {}
+}
+`
+	assert.Equal(t, want, minify(src))
+}
+
+func TestMinify_UnterminatedBlockComment(t *testing.T) {
+	assert.Equal(t, "pragma solidity ^0.8.24;\n", minify("pragma solidity ^0.8.24;\n/* never closed"))
+}
@@ -1,14 +1,221 @@
 package prompts
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/Zubimendi/erc20gen/internal/config"
 )
 
+// Asker abstracts the survey calls so prompts can be driven by tests.
+type Asker interface {
+	Ask(qs []*survey.Question, response interface{}, opts ...survey.AskOpt) error
+	AskOne(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error
+}
+
+type surveyAsker struct{}
+
+func (surveyAsker) Ask(qs []*survey.Question, response interface{}, opts ...survey.AskOpt) error {
+	return survey.Ask(qs, response, opts...)
+}
+
+func (surveyAsker) AskOne(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	return survey.AskOne(p, response, opts...)
+}
+
+// asker is the active prompt backend; tests replace it with a scripted fake.
+var asker Asker = surveyAsker{}
+
+// SetAsker replaces the prompt backend, e.g. with a scripted fake in tests
+// outside this package, and returns a function restoring the previous one.
+func SetAsker(a Asker) (restore func()) {
+	prev := asker
+	asker = a
+	return func() { asker = prev }
+}
+
+// section is one independently re-askable group of prompts.
+type section struct {
+	title string
+	ask   func(cfg *config.TokenConfig) error
+}
+
+// advancedSections walk through every option the prompts offer.
+var advancedSections = []section{
+	{"Name, symbol, decimals & initial supply", askIdentity},
+	{"Supply cap", askSupplyCap},
+	{"Features", askFeatures},
+	{"Access control", askAccessControl},
+	{"Upgradeability", askUpgradeability},
+	{"Output options", askOutputOptions},
+}
+
+// basicSections replace the cap and feature prompts with a single
+// fixed-or-mintable choice, for simple tokens.
+var basicSections = []section{
+	{"Name, symbol, decimals & initial supply", askIdentity},
+	{"Supply model", askSupplyModel},
+	{"Access control", askAccessControl},
+	{"Upgradeability", askUpgradeability},
+	{"Output options", askOutputOptions},
+}
+
+// CollectTokenConfig asks whether to show the advanced options, walks
+// through the matching sections, then lets the user review and re-ask any
+// section before generating.
 func CollectTokenConfig() (*config.TokenConfig, error) {
-	cfg := &config.TokenConfig{}
+	cfg := &config.TokenConfig{SolidityVersion: "^0.8.24"}
+
+	sections := basicSections
+	advanced, err := askAdvanced()
+	if err != nil {
+		return nil, err
+	}
+	if advanced {
+		sections = advancedSections
+	}
 
-	// --- Core identity ---
+	for _, s := range sections {
+		if err := s.ask(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := review(cfg, sections); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// PromptedFlags names the generate flags whose fields CollectTokenConfig
+// answers, so a caller applying flags on top of the answers knows which to
+// leave alone. The --with-* outputs are left out: an explicit flag still
+// overrides the matching prompt.
+var PromptedFlags = map[string]bool{
+	"name": true, "symbol": true, "decimals": true, "initial-supply": true,
+	"max-supply": true, "mintable": true, "burnable": true, "admin-burn": true,
+	"pausable": true, "start-paused": true, "permit": true, "snapshot": true,
+	"votes": true, "clock-mode": true, "access": true, "upgradeable": true,
+	"license": true,
+}
+
+// askAdvanced asks whether to prompt for caps, features and clock modes.
+func askAdvanced() (bool, error) {
+	var advanced bool
+	if err := asker.AskOne(&survey.Confirm{
+		Message: "Advanced options?",
+		Default: false,
+		Help:    "No = a simple fixed or mintable token. Yes = supply cap, features (burn, pause, permit, votes, ...).",
+	}, &advanced); err != nil {
+		return false, err
+	}
+	return advanced, nil
+}
+
+// review shows the summary until the user confirms it, re-asking the
+// section they pick each time they decline.
+func review(cfg *config.TokenConfig, sections []section) error {
+	for {
+		ok, err := ConfirmSummary(cfg)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		titles := make([]string, len(sections))
+		for i, s := range sections {
+			titles[i] = s.title
+		}
+		var choice string
+		if err := asker.AskOne(&survey.Select{
+			Message: "Which section would you like to change?",
+			Options: titles,
+		}, &choice); err != nil {
+			return err
+		}
+		for _, s := range sections {
+			if s.title == choice {
+				if err := s.ask(cfg); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// ConfirmSummary prints the resolved config and asks whether to generate.
+func ConfirmSummary(cfg *config.TokenConfig) (bool, error) {
+	fmt.Println()
+	fmt.Println(Summary(cfg))
+
+	var ok bool
+	if err := asker.AskOne(&survey.Confirm{Message: "Generate these files?", Default: true}, &ok); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// ConfirmUnlimitedMint warns that a mintable token without a supply cap can
+// be inflated without limit and asks whether to generate it anyway.
+func ConfirmUnlimitedMint() (bool, error) {
+	var ok bool
+	if err := asker.AskOne(&survey.Confirm{
+		Message: "Mintable without a max supply allows unlimited inflation. Generate anyway?",
+		Default: false,
+	}, &ok); err != nil {
+		return false, err
+	}
+	return ok, nil
+}
+
+// Summary renders the collected choices as an aligned, human-readable table.
+func Summary(cfg *config.TokenConfig) string {
+	orNone := func(s string) string {
+		if s == "" {
+			return "(none)"
+		}
+		return s
+	}
+	features := cfg.EnabledFeatures()
+
+	var outputs []string
+	if cfg.WithDeploy {
+		outputs = append(outputs, "deploy script")
+	}
+	if cfg.WithTest {
+		outputs = append(outputs, "test skeleton")
+	}
+	if cfg.WithABI {
+		outputs = append(outputs, "ABI JSON")
+	}
+	if cfg.WithReadme {
+		outputs = append(outputs, "README")
+	}
+
+	var b strings.Builder
+	b.WriteString("📋 Summary\n")
+	rows := [][2]string{
+		{"Name", cfg.Name},
+		{"Symbol", cfg.Symbol},
+		{"Decimals", fmt.Sprint(cfg.Decimals)},
+		{"Initial supply", orNone(cfg.InitialSupply)},
+		{"Max supply", orNone(cfg.MaxSupply)},
+		{"Features", orNone(strings.Join(features, ", "))},
+		{"Access control", string(cfg.AccessControl)},
+		{"Upgradeable", string(cfg.Upgradeable)},
+		{"License", cfg.License.String()},
+		{"Extra outputs", orNone(strings.Join(outputs, ", "))},
+	}
+	for _, r := range rows {
+		fmt.Fprintf(&b, "  %-16s %s\n", r[0]+":", r[1])
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// --- Core identity ---
+func askIdentity(cfg *config.TokenConfig) error {
 	var answers struct {
 		Name          string
 		Symbol        string
@@ -16,32 +223,30 @@ func CollectTokenConfig() (*config.TokenConfig, error) {
 		InitialSupply string
 	}
 
-	if err := survey.Ask([]*survey.Question{
-		{
-			Name:     "name",
-			Prompt:   &survey.Input{Message: "Token Name:", Help: "e.g. MyAwesomeToken"},
-			Validate: survey.Required,
-		},
-		{
-			Name:     "symbol",
-			Prompt:   &survey.Input{Message: "Token Symbol (uppercase):", Help: "e.g. MTK — max 11 chars"},
-			Validate: survey.Required,
-		},
+	// Defaults reflect the current values when a section is re-asked.
+	decimals, initialSupply := "18", "1000000"
+	if cfg.Name != "" {
+		decimals, initialSupply = fmt.Sprint(cfg.Decimals), cfg.InitialSupply
+	}
+
+	if err := asker.Ask([]*survey.Question{
+		nameQuestion(cfg.Name),
+		symbolQuestion(cfg.Symbol),
 		{
 			Name: "decimalsStr",
 			Prompt: &survey.Select{
 				Message: "Decimals:",
 				Options: []string{"18", "6", "8", "0"},
-				Default: "18",
+				Default: decimals,
 				Help:    "18 is the Ethereum standard. Use 6 for stablecoins like USDC.",
 			},
 		},
 		{
 			Name:   "initialSupply",
-			Prompt: &survey.Input{Message: "Initial Supply (whole tokens):", Default: "1000000"},
+			Prompt: &survey.Input{Message: "Initial Supply (whole tokens):", Default: initialSupply},
 		},
 	}, &answers); err != nil {
-		return nil, err
+		return err
 	}
 
 	cfg.Name = answers.Name
@@ -58,96 +263,249 @@ func CollectTokenConfig() (*config.TokenConfig, error) {
 	default:
 		cfg.Decimals = 18
 	}
+	return nil
+}
 
-	// --- Supply cap ---
+func nameQuestion(def string) *survey.Question {
+	return &survey.Question{
+		Name:     "name",
+		Prompt:   &survey.Input{Message: "Token Name:", Default: def, Help: "e.g. MyAwesomeToken"},
+		Validate: survey.Required,
+	}
+}
+
+func symbolQuestion(def string) *survey.Question {
+	return &survey.Question{
+		Name:     "symbol",
+		Prompt:   &survey.Input{Message: "Token Symbol (uppercase):", Default: def, Help: "e.g. MTK — max 11 chars"},
+		Validate: survey.Required,
+	}
+}
+
+// FillMissing asks only for the required fields cfg leaves empty — the
+// name and symbol — keeping every value already set by flags. A missing
+// symbol defaults to one derived from the name.
+func FillMissing(cfg *config.TokenConfig) error {
+	var qs []*survey.Question
+	if cfg.Name == "" {
+		qs = append(qs, nameQuestion(""))
+	}
+	if cfg.Symbol == "" {
+		qs = append(qs, symbolQuestion(suggestSymbol(cfg.Name)))
+	}
+	if len(qs) == 0 {
+		return nil
+	}
+
+	var answers struct {
+		Name   string
+		Symbol string
+	}
+	if err := asker.Ask(qs, &answers); err != nil {
+		return err
+	}
+	if cfg.Name == "" {
+		cfg.Name = answers.Name
+	}
+	if cfg.Symbol == "" {
+		cfg.Symbol = answers.Symbol
+	}
+	return nil
+}
+
+// suggestSymbol derives a symbol from a token name: its letters and digits,
+// uppercased and cut to the 11-character limit ("" for an unknown name).
+func suggestSymbol(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if b.Len() == 11 {
+			break
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// --- Supply cap ---
+func askSupplyCap(cfg *config.TokenConfig) error {
 	var hasCap bool
-	if err := survey.AskOne(
-		&survey.Confirm{Message: "Set a maximum supply cap?", Default: false},
+	if err := asker.AskOne(
+		&survey.Confirm{Message: "Set a maximum supply cap?", Default: cfg.MaxSupply != ""},
 		&hasCap,
 	); err != nil {
-		return nil, err
+		return err
 	}
 
+	capDefault := cfg.MaxSupply
+	if capDefault == "" {
+		capDefault = "10000000"
+	}
+	cfg.MaxSupply = ""
 	if hasCap {
 		var cap string
-		if err := survey.AskOne(
-			&survey.Input{Message: "Maximum Supply (whole tokens):", Default: "10000000"},
+		if err := asker.AskOne(
+			&survey.Input{Message: "Maximum Supply (whole tokens):", Default: capDefault},
 			&cap,
 		); err != nil {
-			return nil, err
+			return err
 		}
 		cfg.MaxSupply = cap
 	}
+	return nil
+}
 
-	// --- Feature flags ---
-	var features []string
-	if err := survey.AskOne(&survey.MultiSelect{
+// --- Supply model (basic mode) ---
+func askSupplyModel(cfg *config.TokenConfig) error {
+	model := "fixed"
+	if cfg.Mintable {
+		model = "mintable"
+	}
+	if err := asker.AskOne(&survey.Select{
+		Message: "Supply model:",
+		Options: []string{"fixed", "mintable"},
+		Default: model,
+		Help:    "fixed = the initial supply is all there will ever be. mintable = the owner can mint more later (uncapped).",
+	}, &model); err != nil {
+		return err
+	}
+	cfg.Mintable = model == "mintable"
+	return nil
+}
+
+// featureOption binds a menu label to the TokenConfig flag it controls.
+// Selections are matched by index, so labels can be reworded freely.
+type featureOption struct {
+	label string
+	flag  func(cfg *config.TokenConfig) *bool
+}
+
+var featureOptions = []featureOption{
+	{"Mintable     — owner can mint new tokens", func(c *config.TokenConfig) *bool { return &c.Mintable }},
+	{"Burnable     — holders can burn their tokens", func(c *config.TokenConfig) *bool { return &c.Burnable }},
+	{"Admin burn   — owner can burn from any account", func(c *config.TokenConfig) *bool { return &c.AdminBurn }},
+	{"Pausable     — owner can pause all transfers", func(c *config.TokenConfig) *bool { return &c.Pausable }},
+	{"Permit       — EIP-2612 gasless approvals", func(c *config.TokenConfig) *bool { return &c.Permit }},
+	{"Snapshot     — balance snapshots for governance", func(c *config.TokenConfig) *bool { return &c.Snapshot }},
+	{"Votes        — on-chain voting power", func(c *config.TokenConfig) *bool { return &c.Votes }},
+}
+
+// --- Feature flags ---
+func askFeatures(cfg *config.TokenConfig) error {
+	labels := make([]string, len(featureOptions))
+	var enabled []int
+	for i, o := range featureOptions {
+		labels[i] = o.label
+		if *o.flag(cfg) {
+			enabled = append(enabled, i)
+		}
+	}
+
+	var selected []int
+	if err := asker.AskOne(&survey.MultiSelect{
 		Message: "Select token features:",
-		Options: []string{
-			"Mintable     — owner can mint new tokens",
-			"Burnable     — holders can burn their tokens",
-			"Pausable     — owner can pause all transfers",
-			"Permit       — EIP-2612 gasless approvals",
-			"Snapshot     — balance snapshots for governance",
-			"Votes        — on-chain voting power",
-		},
-		Help: "Space to select, Enter to confirm.",
-	}, &features); err != nil {
-		return nil, err
+		Options: labels,
+		Default: enabled,
+		Help:    "Space to select, Enter to confirm.",
+	}, &selected); err != nil {
+		return err
+	}
+
+	for _, o := range featureOptions {
+		*o.flag(cfg) = false
+	}
+	for _, i := range selected {
+		*featureOptions[i].flag(cfg) = true
 	}
 
-	for _, f := range features {
-		switch f[:8] {
-		case "Mintable":
-			cfg.Mintable = true
-		case "Burnable":
-			cfg.Burnable = true
-		case "Pausable":
-			cfg.Pausable = true
-		case "Permit  ":
-			cfg.Permit = true
-		case "Snapshot":
-			cfg.Snapshot = true
-		case "Votes   ":
-			cfg.Votes = true
+	cfg.ClockMode = config.ClockBlockNumber
+	if cfg.Votes {
+		var clock string
+		if err := asker.AskOne(&survey.Select{
+			Message: "Votes clock mode:",
+			Options: []string{"blocknumber", "timestamp"},
+			Default: "blocknumber",
+			Help:    "blocknumber = OpenZeppelin default. timestamp = recommended on L2s with irregular block times.",
+		}, &clock); err != nil {
+			return err
 		}
+		cfg.ClockMode = config.ClockMode(clock)
 	}
 
-	// --- Access control ---
-	var accessStr string
-	if err := survey.AskOne(&survey.Select{
+	cfg.StartPaused = false
+	if cfg.Pausable {
+		if err := asker.AskOne(
+			&survey.Confirm{Message: "Deploy paused (unpause manually at launch)?", Default: false},
+			&cfg.StartPaused,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Access control ---
+func askAccessControl(cfg *config.TokenConfig) error {
+	accessStr := string(cfg.AccessControl)
+	if accessStr == "" {
+		accessStr = "ownable"
+	}
+	if err := asker.AskOne(&survey.Select{
 		Message: "Access Control Model:",
 		Options: []string{"ownable", "roles", "none"},
-		Default: "ownable",
-		Help:    "ownable = single owner. roles = multi-role with AccessControl. none = no restrictions.",
+		Default: accessStr,
+		Help:    "ownable = single owner. roles = multi-role with AccessControl. none = no restrictions (not allowed with mint, pause, snapshot, or admin burn).",
 	}, &accessStr); err != nil {
-		return nil, err
+		return err
 	}
 	cfg.AccessControl = config.AccessControlType(accessStr)
+	return nil
+}
 
-	// --- Output options ---
+// --- Upgradeability ---
+func askUpgradeability(cfg *config.TokenConfig) error {
+	upgradeStr := string(cfg.Upgradeable)
+	if upgradeStr == "" {
+		upgradeStr = "none"
+	}
+	if err := asker.AskOne(&survey.Select{
+		Message: "Upgradeability:",
+		Options: []string{"none", "uups", "transparent"},
+		Default: upgradeStr,
+		Help:    "none = immutable contract. uups = upgrade logic in the token. transparent = upgrade logic in a ProxyAdmin.",
+	}, &upgradeStr); err != nil {
+		return err
+	}
+	cfg.Upgradeable = config.UpgradeableType(upgradeStr)
+	return nil
+}
+
+// --- Output options ---
+func askOutputOptions(cfg *config.TokenConfig) error {
 	var outputAnswers struct {
 		WithDeploy bool
 		WithTest   bool
+		WithABI    bool
 		License    string
 	}
 
-	if err := survey.Ask([]*survey.Question{
+	if err := asker.Ask([]*survey.Question{
 		{Name: "withDeploy", Prompt: &survey.Confirm{Message: "Generate Hardhat deployment script?", Default: true}},
 		{Name: "withTest", Prompt: &survey.Confirm{Message: "Generate Hardhat test skeleton?", Default: true}},
+		{Name: "withABI", Prompt: &survey.Confirm{Message: "Generate ABI JSON?", Default: false}},
 		{Name: "license", Prompt: &survey.Select{
 			Message: "License:",
 			Options: []string{"MIT", "GPL-3.0", "UNLICENSED", "Apache-2.0"},
 			Default: "MIT",
 		}},
 	}, &outputAnswers); err != nil {
-		return nil, err
+		return err
 	}
 
 	cfg.WithDeploy = outputAnswers.WithDeploy
 	cfg.WithTest = outputAnswers.WithTest
-	cfg.License = outputAnswers.License
-	cfg.SolidityVersion = "^0.8.24"
-
-	return cfg, nil
-}
\ No newline at end of file
+	cfg.WithABI = outputAnswers.WithABI
+	cfg.License = config.LicenseType(outputAnswers.License)
+	return nil
+}
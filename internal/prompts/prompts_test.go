@@ -0,0 +1,300 @@
+package prompts
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─── Helper ──────────────────────────────────────────────────────────────────
+
+// fakeAsker answers prompts from a script keyed by prompt message. Each
+// message holds a queue so the same prompt can be answered differently on
+// successive asks.
+type fakeAsker struct {
+	answers map[string][]interface{}
+	asked   []string
+}
+
+func (f *fakeAsker) answer(p survey.Prompt) (interface{}, error) {
+	var msg string
+	switch p := p.(type) {
+	case *survey.Input:
+		msg = p.Message
+	case *survey.Select:
+		msg = p.Message
+	case *survey.MultiSelect:
+		msg = p.Message
+	case *survey.Confirm:
+		msg = p.Message
+	default:
+		return nil, fmt.Errorf("unsupported prompt %T", p)
+	}
+	f.asked = append(f.asked, msg)
+	queue := f.answers[msg]
+	if len(queue) == 0 {
+		return nil, fmt.Errorf("unexpected prompt %q", msg)
+	}
+	f.answers[msg] = queue[1:]
+	return queue[0], nil
+}
+
+func (f *fakeAsker) Ask(qs []*survey.Question, response interface{}, _ ...survey.AskOpt) error {
+	for _, q := range qs {
+		v, err := f.answer(q.Prompt)
+		if err != nil {
+			return err
+		}
+		if err := core.WriteAnswer(response, q.Name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeAsker) AskOne(p survey.Prompt, response interface{}, _ ...survey.AskOpt) error {
+	v, err := f.answer(p)
+	if err != nil {
+		return err
+	}
+	return core.WriteAnswer(response, "", v)
+}
+
+func withAsker(t *testing.T, answers map[string][]interface{}) *fakeAsker {
+	t.Helper()
+	f := &fakeAsker{answers: answers}
+	prev := asker
+	asker = f
+	t.Cleanup(func() { asker = prev })
+	return f
+}
+
+func baseAnswers() map[string][]interface{} {
+	return map[string][]interface{}{
+		"Advanced options?":                   {true},
+		"Token Name:":                         {"MyToken"},
+		"Token Symbol (uppercase):":           {"MTK"},
+		"Decimals:":                           {"6"},
+		"Initial Supply (whole tokens):":      {"500"},
+		"Set a maximum supply cap?":           {false},
+		"Select token features:":              {[]string{}},
+		"Access Control Model:":               {"ownable"},
+		"Upgradeability:":                     {"none"},
+		"Generate Hardhat deployment script?": {true},
+		"Generate Hardhat test skeleton?":     {false},
+		"Generate ABI JSON?":                  {false},
+		"License:":                            {"MIT"},
+		"Generate these files?":               {true},
+	}
+}
+
+// ─── ConfirmSummary Tests ────────────────────────────────────────────────────
+
+func TestConfirmSummary(t *testing.T) {
+	for _, want := range []bool{true, false} {
+		t.Run(fmt.Sprint(want), func(t *testing.T) {
+			withAsker(t, map[string][]interface{}{"Generate these files?": {want}})
+			ok, err := ConfirmSummary(&config.TokenConfig{Name: "MyToken"})
+			require.NoError(t, err)
+			assert.Equal(t, want, ok)
+		})
+	}
+}
+
+func TestConfirmUnlimitedMint(t *testing.T) {
+	for _, want := range []bool{true, false} {
+		t.Run(fmt.Sprint(want), func(t *testing.T) {
+			withAsker(t, map[string][]interface{}{"Mintable without a max supply allows unlimited inflation. Generate anyway?": {want}})
+			ok, err := ConfirmUnlimitedMint()
+			require.NoError(t, err)
+			assert.Equal(t, want, ok)
+		})
+	}
+}
+
+func TestSummary_ListsChoices(t *testing.T) {
+	cfg := &config.TokenConfig{
+		Name:          "MyToken",
+		Symbol:        "MTK",
+		Decimals:      6,
+		InitialSupply: "500",
+		Mintable:      true,
+		Pausable:      true,
+		AccessControl: config.AccessRoles,
+		License:       "MIT",
+		WithDeploy:    true,
+	}
+	s := Summary(cfg)
+	assert.Contains(t, s, "MyToken")
+	assert.Contains(t, s, "MTK")
+	assert.Contains(t, s, "Mintable, Pausable")
+	assert.Contains(t, s, "roles")
+	assert.Regexp(t, `Max supply:\s+\(none\)`, s)
+	assert.Contains(t, s, "deploy script")
+}
+
+// ─── CollectTokenConfig Tests ────────────────────────────────────────────────
+
+func TestCollectTokenConfig_Confirm(t *testing.T) {
+	withAsker(t, baseAnswers())
+	cfg, err := CollectTokenConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "MyToken", cfg.Name)
+	assert.Equal(t, "MTK", cfg.Symbol)
+	assert.Equal(t, uint8(6), cfg.Decimals)
+	assert.Equal(t, "500", cfg.InitialSupply)
+	assert.Equal(t, config.AccessOwnable, cfg.AccessControl)
+	assert.True(t, cfg.WithDeploy)
+	assert.False(t, cfg.WithTest)
+}
+
+func TestCollectTokenConfig_DeclineReentersSection(t *testing.T) {
+	answers := baseAnswers()
+	answers["Generate these files?"] = []interface{}{false, true}
+	answers["Which section would you like to change?"] = []interface{}{"Access control"}
+	answers["Access Control Model:"] = []interface{}{"ownable", "roles"}
+	f := withAsker(t, answers)
+
+	cfg, err := CollectTokenConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, config.AccessRoles, cfg.AccessControl)
+	assert.Equal(t, "MyToken", cfg.Name, "untouched sections keep their answers")
+
+	var reasked int
+	for _, m := range f.asked {
+		if m == "Token Name:" {
+			reasked++
+		}
+	}
+	assert.Equal(t, 1, reasked, "only the chosen section should be re-asked")
+}
+
+func TestCollectTokenConfig_BasicMode(t *testing.T) {
+	for _, model := range []string{"fixed", "mintable"} {
+		t.Run(model, func(t *testing.T) {
+			answers := baseAnswers()
+			answers["Advanced options?"] = []interface{}{false}
+			answers["Supply model:"] = []interface{}{model}
+			f := withAsker(t, answers)
+
+			cfg, err := CollectTokenConfig()
+			require.NoError(t, err)
+
+			assert.Equal(t, "MyToken", cfg.Name)
+			assert.Equal(t, model == "mintable", cfg.Mintable)
+			assert.Empty(t, cfg.MaxSupply)
+			if model == "mintable" {
+				assert.Equal(t, []string{"Mintable"}, cfg.EnabledFeatures())
+			} else {
+				assert.Empty(t, cfg.EnabledFeatures())
+			}
+			assert.NotContains(t, f.asked, "Set a maximum supply cap?")
+			assert.NotContains(t, f.asked, "Select token features:")
+		})
+	}
+}
+
+func TestCollectTokenConfig_AdvancedMode(t *testing.T) {
+	answers := baseAnswers()
+	answers["Set a maximum supply cap?"] = []interface{}{true}
+	answers["Maximum Supply (whole tokens):"] = []interface{}{"1000"}
+	answers["Select token features:"] = []interface{}{[]int{0, 1}}
+	f := withAsker(t, answers)
+
+	cfg, err := CollectTokenConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1000", cfg.MaxSupply)
+	assert.True(t, cfg.Mintable)
+	assert.True(t, cfg.Burnable)
+	assert.Contains(t, f.asked, "Select token features:")
+	assert.NotContains(t, f.asked, "Supply model:")
+}
+
+func TestCollectTokenConfig_BasicModeReviewOffersBasicSections(t *testing.T) {
+	answers := baseAnswers()
+	answers["Advanced options?"] = []interface{}{false}
+	answers["Supply model:"] = []interface{}{"fixed", "mintable"}
+	answers["Generate these files?"] = []interface{}{false, true}
+	answers["Which section would you like to change?"] = []interface{}{"Supply model"}
+	withAsker(t, answers)
+
+	cfg, err := CollectTokenConfig()
+	require.NoError(t, err)
+	assert.True(t, cfg.Mintable)
+}
+
+// ─── Feature Option Tests ────────────────────────────────────────────────────
+
+func TestAskFeatures_MapsOptionsToFlags(t *testing.T) {
+	prev := featureOptions
+	t.Cleanup(func() { featureOptions = prev })
+
+	// Reword every label; the selection must still land on the right flag.
+	featureOptions = append([]featureOption(nil), prev...)
+	for i := range featureOptions {
+		featureOptions[i].label = fmt.Sprintf("Reworded option #%d", i)
+	}
+
+	flags := []struct {
+		name string
+		get  func(*config.TokenConfig) bool
+	}{
+		{"Mintable", func(c *config.TokenConfig) bool { return c.Mintable }},
+		{"Burnable", func(c *config.TokenConfig) bool { return c.Burnable }},
+		{"AdminBurn", func(c *config.TokenConfig) bool { return c.AdminBurn }},
+		{"Pausable", func(c *config.TokenConfig) bool { return c.Pausable }},
+		{"Permit", func(c *config.TokenConfig) bool { return c.Permit }},
+		{"Snapshot", func(c *config.TokenConfig) bool { return c.Snapshot }},
+		{"Votes", func(c *config.TokenConfig) bool { return c.Votes }},
+	}
+	require.Len(t, featureOptions, len(flags))
+
+	for i, want := range flags {
+		t.Run(want.name, func(t *testing.T) {
+			answers := map[string][]interface{}{"Select token features:": {[]int{i}}}
+			if want.name == "Pausable" {
+				answers["Deploy paused (unpause manually at launch)?"] = []interface{}{false}
+			}
+			if want.name == "Votes" {
+				answers["Votes clock mode:"] = []interface{}{"blocknumber"}
+			}
+			withAsker(t, answers)
+
+			cfg := &config.TokenConfig{}
+			require.NoError(t, askFeatures(cfg))
+			for _, f := range flags {
+				assert.Equal(t, f.name == want.name, f.get(cfg), "flag %s", f.name)
+			}
+		})
+	}
+}
+
+// ─── FillMissing Tests ───────────────────────────────────────────────────────
+
+func TestFillMissing_AsksOnlyForUnsetFields(t *testing.T) {
+	f := withAsker(t, map[string][]interface{}{"Token Symbol (uppercase):": {"MTK"}})
+	cfg := &config.TokenConfig{Name: "My Token", Decimals: 6}
+	require.NoError(t, FillMissing(cfg))
+	assert.Equal(t, []string{"Token Symbol (uppercase):"}, f.asked)
+	assert.Equal(t, "My Token", cfg.Name)
+	assert.Equal(t, "MTK", cfg.Symbol)
+	assert.Equal(t, uint8(6), cfg.Decimals)
+
+	f = withAsker(t, map[string][]interface{}{})
+	require.NoError(t, FillMissing(cfg))
+	assert.Empty(t, f.asked)
+}
+
+func TestSuggestSymbol(t *testing.T) {
+	assert.Equal(t, "MYTOKEN", suggestSymbol("My Token"))
+	assert.Equal(t, "GOVERNANCE2", suggestSymbol("governance-2-token"))
+	assert.Equal(t, "", suggestSymbol(""))
+}
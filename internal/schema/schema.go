@@ -0,0 +1,165 @@
+// Package schema derives a JSON Schema (draft 2020-12) for the token config
+// file from TokenConfig itself: each field's flag tag names the key, its Go
+// type gives the JSON type, the generate flag supplies the description and
+// default, and config.FieldConstraints adds the limits Validate enforces.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/spf13/pflag"
+)
+
+// Draft is the JSON Schema dialect of the generated schema.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is the root of the config-file schema.
+type Schema struct {
+	Schema      string     `json:"$schema"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Type        string     `json:"type"`
+	Properties  Properties `json:"properties"`
+	Required    []string   `json:"required"`
+}
+
+// Property describes one config-file key.
+type Property struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Pattern     string      `json:"pattern,omitempty"`
+	Minimum     *int64      `json:"minimum,omitempty"`
+	Maximum     *int64      `json:"maximum,omitempty"`
+	Items       *Property   `json:"items,omitempty"`
+}
+
+// NamedProperty is a Property under its config-file key.
+type NamedProperty struct {
+	Name string
+	Property
+}
+
+// Properties marshals as a JSON object that keeps TokenConfig field order.
+type Properties []NamedProperty
+
+// MarshalJSON implements json.Marshaler.
+func (ps Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, p := range ps {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false) // usage strings contain "<address>"
+		if err := enc.Encode(p.Name); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		if err := enc.Encode(p.Property); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// enumer is implemented by the enumerated TokenConfig field types.
+type enumer interface {
+	Values() []string
+}
+
+// Generate builds the schema of every TokenConfig field with a flag tag.
+// flags must define each tagged flag; their usage strings and defaults
+// become the property descriptions and defaults.
+func Generate(flags *pflag.FlagSet) (*Schema, error) {
+	s := &Schema{
+		Schema:      Draft,
+		Title:       "erc20gen token config",
+		Description: "Keys accepted in an erc20gen config file (--config); each mirrors the generate flag of the same name.",
+		Type:        "object",
+		Required:    []string{},
+	}
+	constraints := config.FieldConstraints()
+
+	t := reflect.TypeOf(config.TokenConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := field.Tag.Lookup("flag")
+		if !ok {
+			continue
+		}
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return nil, fmt.Errorf("TokenConfig.%s: no --%s flag", field.Name, name)
+		}
+		prop, err := property(field.Type, flag)
+		if err != nil {
+			return nil, fmt.Errorf("TokenConfig.%s: %w", field.Name, err)
+		}
+
+		c := constraints[field.Name]
+		target := &prop
+		if prop.Items != nil {
+			target = prop.Items
+		}
+		target.Pattern = c.Pattern
+		if c.Pattern != "" && !c.Required && prop.Items == nil {
+			// Validate only checks optional strings once they are set.
+			target.Pattern = "^$|" + c.Pattern
+		}
+		if c.Enum != nil {
+			target.Enum = c.Enum
+		}
+		prop.Minimum, prop.Maximum = c.Minimum, c.Maximum
+		if c.Required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties = append(s.Properties, NamedProperty{Name: name, Property: prop})
+	}
+	return s, nil
+}
+
+// property maps a field's Go type to its JSON type and reads the
+// description and default from the field's flag.
+func property(t reflect.Type, flag *pflag.Flag) (Property, error) {
+	p := Property{Description: flag.Usage}
+	switch t.Kind() {
+	case reflect.String:
+		p.Type = "string"
+		if flag.DefValue != "" {
+			p.Default = flag.DefValue
+		}
+		if e, ok := reflect.Zero(t).Interface().(enumer); ok {
+			p.Enum = e.Values()
+		}
+	case reflect.Bool:
+		p.Type = "boolean"
+		v, err := strconv.ParseBool(flag.DefValue)
+		if err != nil {
+			return p, err
+		}
+		p.Default = v
+	case reflect.Int, reflect.Int64, reflect.Uint8:
+		p.Type = "integer"
+		v, err := strconv.ParseInt(flag.DefValue, 10, 64)
+		if err != nil {
+			return p, err
+		}
+		p.Default = v
+	case reflect.Slice:
+		// List keys hold the flag's string form (e.g. "<address>=<amount>").
+		p.Type = "array"
+		p.Items = &Property{Type: "string"}
+	default:
+		return p, fmt.Errorf("unsupported field type %s", t)
+	}
+	return p, nil
+}
@@ -0,0 +1,102 @@
+// Package solc checks that a generated contract compiles by shelling out to
+// a locally installed Solidity compiler (solc, or solcjs from npm).
+package solc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when neither solc nor solcjs is on PATH.
+var ErrNotFound = errors.New("no Solidity compiler found on PATH (looked for solc, solcjs)")
+
+// CompileError carries the compiler's diagnostics for a failed build.
+type CompileError struct {
+	Binary string
+	Output string
+}
+
+func (e *CompileError) Error() string {
+	return fmt.Sprintf("%s failed:\n%s", e.Binary, e.Output)
+}
+
+// Compile compiles the Solidity file at path. OpenZeppelin imports resolve
+// against the nearest node_modules directory above path, or path's own
+// directory when there is none.
+func Compile(path string) error {
+	return CompileContext(context.Background(), path)
+}
+
+// CompileContext is Compile, killing the compiler once ctx is done.
+func CompileContext(ctx context.Context, path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	root := projectRoot(filepath.Dir(abs))
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	bin, err := exec.LookPath("solc")
+	if err == nil {
+		args = []string{
+			"@openzeppelin/=node_modules/@openzeppelin/",
+			"--base-path", ".",
+			"--allow-paths", ".",
+			rel,
+		}
+	} else if bin, err = exec.LookPath("solcjs"); err == nil {
+		// solcjs has no remappings; include-path covers the same imports.
+		// It refuses to run without an output selection, so write the ABI
+		// to a throwaway directory.
+		tmp, err := os.MkdirTemp("", "erc20gen-solcjs-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		args = []string{"--abi", "--base-path", ".", "--include-path", "node_modules", "-o", tmp, rel}
+	} else {
+		return ErrNotFound
+	}
+
+	var out bytes.Buffer
+	c := exec.CommandContext(ctx, bin, args...) // #nosec G204 -- binary from PATH, args built here
+	c.Dir = root
+	c.Stdout = &out
+	c.Stderr = &out
+	if err := c.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("failed to run %s: %w", filepath.Base(bin), err)
+		}
+		return &CompileError{Binary: filepath.Base(bin), Output: strings.TrimSpace(out.String())}
+	}
+	return nil
+}
+
+// projectRoot walks up from dir to the first directory containing
+// node_modules, falling back to dir itself.
+func projectRoot(dir string) string {
+	for d := dir; ; {
+		if fi, err := os.Stat(filepath.Join(d, "node_modules")); err == nil && fi.IsDir() {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
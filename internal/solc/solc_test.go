@@ -0,0 +1,97 @@
+package solc_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Zubimendi/erc20gen/internal/solc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCompiler installs an executable named bin that runs script as the
+// only entry on PATH.
+func fakeCompiler(t *testing.T, bin, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake compiler is a shell script")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, bin), []byte("#!/bin/sh\n"+script), 0o755))
+	t.Setenv("PATH", dir)
+}
+
+func writeContract(t *testing.T) (root, path string) {
+	t.Helper()
+	root = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "node_modules"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "contracts"), 0o755))
+	path = filepath.Join(root, "contracts", "Token.sol")
+	require.NoError(t, os.WriteFile(path, []byte("// SPDX-License-Identifier: MIT\n"), 0o644))
+	return root, path
+}
+
+func TestCompile_Success(t *testing.T) {
+	root, path := writeContract(t)
+	// Record the arguments and working directory the compiler saw.
+	fakeCompiler(t, "solc", `pwd > "$0.dir"; echo "$@" > "$0.args"; exit 0`+"\n")
+
+	require.NoError(t, solc.Compile(path))
+
+	bin := filepath.Join(filepath.SplitList(os.Getenv("PATH"))[0], "solc")
+	dir, err := os.ReadFile(bin + ".dir")
+	require.NoError(t, err)
+	wantRoot, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	gotRoot, err := filepath.EvalSymlinks(string(dir[:len(dir)-1]))
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot, "compiler must run from the node_modules root")
+
+	args, err := os.ReadFile(bin + ".args")
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "@openzeppelin/=node_modules/@openzeppelin/")
+	assert.Contains(t, string(args), filepath.Join("contracts", "Token.sol"))
+}
+
+func TestCompile_FailureSurfacesDiagnostics(t *testing.T) {
+	_, path := writeContract(t)
+	fakeCompiler(t, "solc", "echo 'ParserError: Expected pragma' >&2; exit 1\n")
+
+	err := solc.Compile(path)
+	require.Error(t, err)
+	var ce *solc.CompileError
+	require.True(t, errors.As(err, &ce))
+	assert.Equal(t, "solc", ce.Binary)
+	assert.Contains(t, ce.Output, "ParserError: Expected pragma")
+}
+
+func TestCompile_FallsBackToSolcjs(t *testing.T) {
+	_, path := writeContract(t)
+	fakeCompiler(t, "solcjs", "exit 0\n")
+
+	assert.NoError(t, solc.Compile(path))
+}
+
+func TestCompile_NotFound(t *testing.T) {
+	_, path := writeContract(t)
+	t.Setenv("PATH", t.TempDir())
+
+	assert.ErrorIs(t, solc.Compile(path), solc.ErrNotFound)
+}
+
+func TestCompileContext_KillsCompilerWhenDone(t *testing.T) {
+	_, path := writeContract(t)
+	fakeCompiler(t, "solc", "while :; do :; done\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := solc.CompileContext(ctx, path)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
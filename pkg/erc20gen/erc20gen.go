@@ -0,0 +1,443 @@
+// Package erc20gen is the library entry point to erc20gen: build a Config,
+// call Generate, and get every generated file back in memory, keyed by its
+// project-relative path. Nothing touches the filesystem.
+//
+//	cfg := erc20gen.NewConfig("MyToken", "MTK")
+//	cfg.InitialSupply = "1000000"
+//	cfg.Mintable = true
+//	cfg.WithTest = true
+//	files, err := erc20gen.Generate(cfg, erc20gen.Options{})
+//	// files["contracts/MyToken.sol"], files["test/MyToken.test.js"]
+package erc20gen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Zubimendi/erc20gen/internal/abi"
+	"github.com/Zubimendi/erc20gen/internal/config"
+	"github.com/Zubimendi/erc20gen/internal/generator"
+)
+
+// Version is the erc20gen release stamped into generated files.
+const Version = generator.Version
+
+// Config describes the token to generate. See NewConfig for defaults.
+type Config = config.TokenConfig
+
+// ValidationError lists every problem Validate found; FieldError is one of them.
+type (
+	ValidationError = config.ValidationError
+	FieldError      = config.FieldError
+)
+
+// Enumerated Config field types.
+type (
+	AccessControlType = config.AccessControlType
+	UpgradeableType   = config.UpgradeableType
+	MintScheduleType  = config.MintScheduleType
+	ClockMode         = config.ClockMode
+	OZVersion         = config.OZVersion
+	DeployStyle       = config.DeployStyle
+	TestStyle         = config.TestStyle
+	LineEnding        = config.LineEnding
+	LicenseType       = config.LicenseType
+	ContractStyle     = config.ContractStyle
+	SymbolCase        = config.SymbolCase
+	EVMVersion        = config.EVMVersion
+	GuardStyle        = config.GuardStyle
+)
+
+// Values of the enumerated Config fields.
+const (
+	AccessOwnable = config.AccessOwnable
+	AccessRoles   = config.AccessRoles
+	AccessNone    = config.AccessNone
+
+	UpgradeNone        = config.UpgradeNone
+	UpgradeUUPS        = config.UpgradeUUPS
+	UpgradeTransparent = config.UpgradeTransparent
+
+	MintScheduleNone   = config.MintScheduleNone
+	MintScheduleLinear = config.MintScheduleLinear
+
+	ClockBlockNumber = config.ClockBlockNumber
+	ClockTimestamp   = config.ClockTimestamp
+
+	OZv4 = config.OZv4
+	OZv5 = config.OZv5
+
+	DeployStyleEthers        = config.DeployStyleEthers
+	DeployStyleHardhatDeploy = config.DeployStyleHardhatDeploy
+
+	TestStyleEthersJS = config.TestStyleEthersJS
+	TestStyleViemTS   = config.TestStyleViemTS
+
+	LineEndingLF   = config.LineEndingLF
+	LineEndingCRLF = config.LineEndingCRLF
+
+	SymbolCaseAutoUpper = config.SymbolCaseAutoUpper
+	SymbolCaseStrict    = config.SymbolCaseStrict
+
+	EVMLondon   = config.EVMLondon
+	EVMParis    = config.EVMParis
+	EVMShanghai = config.EVMShanghai
+	EVMCancun   = config.EVMCancun
+	EVMPrague   = config.EVMPrague
+
+	GuardRequire  = config.GuardRequire
+	GuardIfRevert = config.GuardIfRevert
+
+	StyleStandard = config.StyleStandard
+	StyleMinimal  = config.StyleMinimal
+	StyleVerbose  = config.StyleVerbose
+
+	LicenseMIT          = config.LicenseMIT
+	LicenseApache20     = config.LicenseApache20
+	LicenseGPL20Only    = config.LicenseGPL20Only
+	LicenseGPL20OrLater = config.LicenseGPL20OrLater
+	LicenseGPL30        = config.LicenseGPL30
+	LicenseGPL30Only    = config.LicenseGPL30Only
+	LicenseGPL30OrLater = config.LicenseGPL30OrLater
+	LicenseLGPL21Only   = config.LicenseLGPL21Only
+	LicenseLGPL30       = config.LicenseLGPL30
+	LicenseLGPL30Only   = config.LicenseLGPL30Only
+	LicenseAGPL30       = config.LicenseAGPL30
+	LicenseAGPL30Only   = config.LicenseAGPL30Only
+	LicenseBSD2Clause   = config.LicenseBSD2Clause
+	LicenseBSD3Clause   = config.LicenseBSD3Clause
+	LicenseMPL20        = config.LicenseMPL20
+	LicenseISC          = config.LicenseISC
+	LicenseBUSL11       = config.LicenseBUSL11
+	LicenseCC010        = config.LicenseCC010
+	LicenseUnlicense    = config.LicenseUnlicense
+	LicenseUnlicensed   = config.LicenseUnlicensed
+)
+
+// Layout names accepted by Options.Layout.
+const (
+	LayoutHardhat = "hardhat"
+	LayoutFoundry = "foundry"
+	LayoutFlat    = "flat"
+)
+
+// NewConfig returns a Config with the same defaults as `erc20gen generate`:
+// 18 decimals, Ownable, not upgradeable, MIT, OpenZeppelin v5.
+func NewConfig(name, symbol string) *Config {
+	return &Config{
+		Name:          name,
+		Symbol:        symbol,
+		SymbolCase:    SymbolCaseAutoUpper,
+		Decimals:      18,
+		AccessControl: AccessOwnable,
+		Upgradeable:   UpgradeNone,
+		MintSchedule:  MintScheduleNone,
+		ClockMode:     ClockBlockNumber,
+
+		GovernorVotingDelay:   config.DefaultVotingDelay,
+		GovernorVotingPeriod:  config.DefaultVotingPeriod,
+		GovernorQuorumPercent: config.DefaultQuorumPercent,
+		BurnRebaseMaxBps:      config.DefaultBurnRebaseMaxBps,
+		License:               LicenseMIT,
+		OZVersion:             OZv5,
+		SolidityVersion:       "^0.8.24",
+		DeployStyle:           DeployStyleEthers,
+		TestStyle:             TestStyleEthersJS,
+		Style:                 StyleStandard,
+	}
+}
+
+// Options controls what Generate emits beyond the Config itself.
+type Options struct {
+	// Layout places files like the CLI's --layout ("" = hardhat).
+	Layout string
+
+	// Seed drives placeholder values such as sample holder addresses.
+	// The same Config and Seed always produce byte-identical output.
+	Seed int64
+
+	// SingleFile bundles a commented-out deploy snippet into the contract
+	// (for Remix) and skips the separate deploy script.
+	SingleFile bool
+
+	// Logger receives a debug record for every template rendered
+	// (nil = no logging).
+	Logger *slog.Logger
+}
+
+// Paths is the project-relative, slash-separated destination of every
+// file Generate can emit.
+type Paths struct {
+	Contract   string
+	Governor   string
+	Deploy     string
+	Test       string
+	Invariants string
+	ABI        string
+	CI         string
+	Env        string
+	Typechain  string
+	Compiler   string
+	Foundry    string
+	Readme     string
+}
+
+// LayoutPaths computes where each file lands for a layout:
+//
+//	hardhat: contracts/, scripts/, test/
+//	foundry: src/, script/, test/
+//	flat:    everything in the project root
+//
+// A hardhat-deploy script goes to deploy/ instead, where the plugin looks
+// for it. The project README, .env.example, typechain.config.js,
+// compiler.config.js and foundry.toml always land in the root, and the CI
+// workflow in .github/workflows/ where GitHub Actions looks for it.
+func LayoutPaths(cfg *Config, layout string) (Paths, error) {
+	var contractDir, deployDir, testDir string
+	switch layout {
+	case LayoutHardhat, "":
+		contractDir, deployDir, testDir = "contracts/", "scripts/", "test/"
+	case LayoutFoundry:
+		contractDir, deployDir, testDir = "src/", "script/", "test/"
+	case LayoutFlat:
+		contractDir, deployDir, testDir = "", "", ""
+	default:
+		return Paths{}, fmt.Errorf("invalid layout %q — must be: hardhat, foundry, or flat", layout)
+	}
+	if cfg.UsesHardhatDeploy() && layout != LayoutFlat {
+		deployDir = "deploy/"
+	}
+
+	return Paths{
+		Contract:   contractDir + cfg.ContractFileName(),
+		Governor:   contractDir + cfg.GovernorFileName(),
+		Deploy:     deployDir + "deploy_" + cfg.SafeName() + ".js",
+		Test:       testDir + cfg.TestFileName(),
+		Invariants: testDir + cfg.InvariantTestFileName(),
+		ABI:        contractDir + cfg.SafeName() + ".abi.json",
+		CI:         ".github/workflows/contracts.yml",
+		Env:        ".env.example",
+		Typechain:  "typechain.config.js",
+		Compiler:   "compiler.config.js",
+		Foundry:    "foundry.toml",
+		Readme:     "README.md",
+	}, nil
+}
+
+// Generate validates cfg and renders the contract plus every optional file
+// cfg asks for (WithGovernor, WithDeploy, WithTest, WithABI, WithCI,
+// WithEnv, WithTypechain, WithReadme). WithTest also writes a Foundry
+// invariant test in the foundry layout, and EVMVersion a compiler config
+// (plus foundry.toml in the foundry layout). The result maps each file's
+// LayoutPaths path to its content.
+func Generate(cfg *Config, opts Options) (map[string]string, error) {
+	return GenerateContext(context.Background(), cfg, opts)
+}
+
+// GenerateContext is Generate, stopping before the next file (or mid-file)
+// once ctx is done. It then returns ctx.Err() and no files.
+func GenerateContext(ctx context.Context, cfg *Config, opts Options) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	layout := opts.Layout
+	if layout == "" {
+		layout = LayoutHardhat
+	}
+	paths, err := LayoutPaths(cfg, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := generator.NewWithSeed(cfg, opts.Seed).WithLogger(opts.Logger)
+	files := make(map[string]string)
+	project := generator.ProjectFiles{Layout: layout, Contract: paths.Contract}
+
+	var contract string
+	if opts.SingleFile {
+		contract, err = gen.GenerateSingleFileCtx(ctx)
+	} else {
+		contract, err = gen.GenerateContractCtx(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("contract generation failed: %w", err)
+	}
+	files[paths.Contract] = contract
+
+	if cfg.WithGovernor {
+		governor, err := gen.GenerateGovernorCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("governor generation failed: %w", err)
+		}
+		files[paths.Governor] = governor
+		project.Governor = paths.Governor
+	}
+
+	if cfg.WithDeploy && !opts.SingleFile {
+		deploy, err := gen.GenerateDeployScriptCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("deploy script generation failed: %w", err)
+		}
+		files[paths.Deploy] = deploy
+		project.Deploy = paths.Deploy
+	}
+
+	if cfg.WithTest {
+		test, err := gen.GenerateTestSkeletonCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("test skeleton generation failed: %w", err)
+		}
+		files[paths.Test] = test
+		project.Test = paths.Test
+
+		if layout == LayoutFoundry {
+			project.Invariants = paths.Invariants
+			invariants, err := gen.GenerateInvariantTestCtx(ctx, project)
+			if err != nil {
+				return nil, fmt.Errorf("invariant test generation failed: %w", err)
+			}
+			files[paths.Invariants] = invariants
+		}
+	}
+
+	if cfg.WithABI {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		abiJSON, err := abi.JSON(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("ABI generation failed: %w", err)
+		}
+		files[paths.ABI] = string(abiJSON)
+		project.ABI = paths.ABI
+	}
+
+	if cfg.EVMVersion != "" {
+		compiler, err := gen.GenerateCompilerConfigCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("compiler config generation failed: %w", err)
+		}
+		files[paths.Compiler] = compiler
+		project.Compiler = paths.Compiler
+
+		if layout == LayoutFoundry {
+			foundry, err := gen.GenerateFoundryConfigCtx(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("foundry.toml generation failed: %w", err)
+			}
+			files[paths.Foundry] = foundry
+			project.Foundry = paths.Foundry
+		}
+	}
+
+	if cfg.WithCI {
+		workflow, err := gen.GenerateCIWorkflowCtx(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("CI workflow generation failed: %w", err)
+		}
+		files[paths.CI] = workflow
+		project.CI = paths.CI
+	}
+
+	if cfg.WithEnv {
+		env, err := gen.GenerateEnvExampleCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(".env.example generation failed: %w", err)
+		}
+		files[paths.Env] = env
+		project.Env = paths.Env
+	}
+
+	if cfg.WithTypechain {
+		typechain, err := gen.GenerateTypechainConfigCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("TypeChain config generation failed: %w", err)
+		}
+		files[paths.Typechain] = typechain
+		project.Typechain = paths.Typechain
+	}
+
+	// The README lists only the files generated above.
+	if cfg.WithReadme {
+		readme, err := gen.GenerateReadmeCtx(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("README generation failed: %w", err)
+		}
+		files[paths.Readme] = readme
+	}
+
+	for p, src := range files {
+		files[p] = generator.Format(cfg, p, src)
+	}
+	return files, nil
+}
+
+// WriteContract validates cfg and writes the contract Generate would store
+// under Paths.Contract to w, executing the template straight into w instead
+// of building the file in memory. Only a contract that is reformatted
+// (Indent, EOL), minified, or bundled with opts.SingleFile is buffered
+// first. On error w may hold part of the file.
+func WriteContract(ctx context.Context, w io.Writer, cfg *Config, opts Options) error {
+	return writeFile(ctx, w, cfg, opts, func(p Paths) string { return p.Contract },
+		func(gen *generator.Generator, w io.Writer) error {
+			if !opts.SingleFile {
+				return gen.GenerateContractToCtx(ctx, w)
+			}
+			src, err := gen.GenerateSingleFileCtx(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, src)
+			return err
+		})
+}
+
+// WriteDeployScript is WriteContract for the deploy script Generate would
+// store under Paths.Deploy. It ignores cfg.WithDeploy and opts.SingleFile.
+func WriteDeployScript(ctx context.Context, w io.Writer, cfg *Config, opts Options) error {
+	return writeFile(ctx, w, cfg, opts, func(p Paths) string { return p.Deploy },
+		func(gen *generator.Generator, w io.Writer) error { return gen.GenerateDeployScriptToCtx(ctx, w) })
+}
+
+// WriteTest is WriteContract for the test skeleton Generate would store
+// under Paths.Test. It ignores cfg.WithTest.
+func WriteTest(ctx context.Context, w io.Writer, cfg *Config, opts Options) error {
+	return writeFile(ctx, w, cfg, opts, func(p Paths) string { return p.Test },
+		func(gen *generator.Generator, w io.Writer) error { return gen.GenerateTestSkeletonToCtx(ctx, w) })
+}
+
+// writeFile validates cfg and renders one file into w, buffering it only
+// when Format has to rewrite it.
+func writeFile(ctx context.Context, w io.Writer, cfg *Config, opts Options, path func(Paths) string, render func(*generator.Generator, io.Writer) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	layout := opts.Layout
+	if layout == "" {
+		layout = LayoutHardhat
+	}
+	paths, err := LayoutPaths(cfg, layout)
+	if err != nil {
+		return err
+	}
+
+	gen := generator.NewWithSeed(cfg, opts.Seed).WithLogger(opts.Logger)
+	if !generator.Reformats(cfg) {
+		return render(gen, w)
+	}
+	var buf strings.Builder
+	if err := render(gen, &buf); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, generator.Format(cfg, path(paths), buf.String()))
+	return err
+}
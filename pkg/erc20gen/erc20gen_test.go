@@ -0,0 +1,215 @@
+package erc20gen_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/Zubimendi/erc20gen/pkg/erc20gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func multiFeatureConfig() *erc20gen.Config {
+	cfg := erc20gen.NewConfig("Library Token", "LIB")
+	cfg.InitialSupply = "1000000"
+	cfg.MaxSupply = "5000000"
+	cfg.Mintable = true
+	cfg.Burnable = true
+	cfg.Pausable = true
+	cfg.Permit = true
+	cfg.AccessControl = erc20gen.AccessRoles
+	cfg.WithDeploy = true
+	cfg.WithTest = true
+	cfg.WithABI = true
+	cfg.WithReadme = true
+	return cfg
+}
+
+func keys(m map[string]string) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+func TestGenerate_MultiFeatureConfig(t *testing.T) {
+	files, err := erc20gen.Generate(multiFeatureConfig(), erc20gen.Options{Seed: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"README.md",
+		"contracts/Library_Token.abi.json",
+		"contracts/Library_Token.sol",
+		"scripts/deploy_Library_Token.js",
+		"test/Library_Token.test.js",
+	}, keys(files))
+
+	contract := files["contracts/Library_Token.sol"]
+	assert.Contains(t, contract, "contract Library_Token is")
+	assert.Contains(t, contract, "ERC20Capped(5_000_000_000_000_000_000_000_000)")
+	assert.Contains(t, contract, "function mint(address to, uint256 amount) external onlyRole(MINTER_ROLE)")
+	assert.Contains(t, contract, "ERC20Pausable")
+	assert.Contains(t, contract, "ERC20Permit")
+
+	var entries []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(files["contracts/Library_Token.abi.json"]), &entries))
+	assert.NotEmpty(t, entries)
+
+	assert.Contains(t, files["README.md"], "`test/Library_Token.test.js`")
+}
+
+func TestGenerate_IsDeterministicForASeed(t *testing.T) {
+	a, err := erc20gen.Generate(multiFeatureConfig(), erc20gen.Options{Seed: 42})
+	require.NoError(t, err)
+	b, err := erc20gen.Generate(multiFeatureConfig(), erc20gen.Options{Seed: 42})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
+
+func TestGenerate_ContractOnlyByDefault(t *testing.T) {
+	files, err := erc20gen.Generate(erc20gen.NewConfig("Plain", "PLN"), erc20gen.Options{Layout: erc20gen.LayoutFoundry})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/Plain.sol"}, keys(files))
+}
+
+func TestGenerate_WithGovernor(t *testing.T) {
+	cfg := erc20gen.NewConfig("Gov Token", "GOV")
+	cfg.Votes = true
+	cfg.WithGovernor = true
+	cfg.WithReadme = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "contracts/Gov_Token.sol", "contracts/Gov_TokenGovernor.sol"}, keys(files))
+	assert.Contains(t, files["contracts/Gov_TokenGovernor.sol"], "GovernorVotesQuorumFraction(4)")
+	assert.Contains(t, files["README.md"], "`contracts/Gov_TokenGovernor.sol`")
+}
+
+func TestGenerate_WithCI(t *testing.T) {
+	cfg := erc20gen.NewConfig("CI Token", "CIT")
+	cfg.WithTest = true
+	cfg.WithCI = true
+	cfg.WithReadme = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{Layout: erc20gen.LayoutFoundry})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/contracts.yml", "README.md", "src/CI_Token.sol", "test/CI_Token.test.js", "test/CI_TokenInvariants.t.sol"}, keys(files))
+	assert.Contains(t, files[".github/workflows/contracts.yml"], "run: forge test")
+	assert.Contains(t, files[".github/workflows/contracts.yml"], "run: npx hardhat test test/CI_Token.test.js")
+	assert.Contains(t, files["README.md"], "`.github/workflows/contracts.yml`")
+	assert.Contains(t, files[".github/workflows/contracts.yml"], "forge-std")
+	assert.Contains(t, files["README.md"], "forge test --match-contract CI_TokenInvariantTest")
+}
+
+func TestGenerate_WithEnv(t *testing.T) {
+	cfg := erc20gen.NewConfig("Env Token", "ENV")
+	cfg.WithDeploy = true
+	cfg.WithEnv = true
+	cfg.WithReadme = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".env.example", "README.md", "contracts/Env_Token.sol", "scripts/deploy_Env_Token.js"}, keys(files))
+	assert.Contains(t, files[".env.example"], "\nDEPLOYER_PRIVATE_KEY=\n")
+	assert.Contains(t, files["README.md"], "Copy `.env.example` to `.env`")
+}
+
+func TestGenerate_WithTypechain(t *testing.T) {
+	cfg := erc20gen.NewConfig("Typed", "TYP")
+	cfg.WithTypechain = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{Layout: erc20gen.LayoutFlat})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Typed.sol", "typechain.config.js"}, keys(files))
+	assert.Contains(t, files["typechain.config.js"], "typechain: {")
+}
+
+func TestGenerate_WithEVMVersion(t *testing.T) {
+	cfg := erc20gen.NewConfig("Paris", "PAR")
+	cfg.EVMVersion = erc20gen.EVMParis
+	cfg.WithCI = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/contracts.yml", "compiler.config.js", "contracts/Paris.sol"}, keys(files))
+	assert.Contains(t, files["compiler.config.js"], `evmVersion: "paris"`)
+
+	files, err = erc20gen.Generate(cfg, erc20gen.Options{Layout: erc20gen.LayoutFoundry})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".github/workflows/contracts.yml", "compiler.config.js", "foundry.toml", "src/Paris.sol"}, keys(files))
+	assert.Contains(t, files["foundry.toml"], `evm_version = "paris"`)
+	assert.Contains(t, files[".github/workflows/contracts.yml"], "# forge reads foundry.toml, which maps @openzeppelin/")
+}
+
+func TestGenerate_SingleFileSkipsDeployScript(t *testing.T) {
+	cfg := erc20gen.NewConfig("Remix", "RMX")
+	cfg.WithDeploy = true
+	files, err := erc20gen.Generate(cfg, erc20gen.Options{Layout: erc20gen.LayoutFlat, SingleFile: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Remix.sol"}, keys(files))
+}
+
+func TestGenerate_ReturnsValidationError(t *testing.T) {
+	cfg := erc20gen.NewConfig("Bad", "ba-d")
+	_, err := erc20gen.Generate(cfg, erc20gen.Options{})
+
+	var verr *erc20gen.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Equal(t, "Symbol", verr.Fields[0].Field)
+}
+
+func TestLayoutPaths_HardhatDeployUsesDeployDir(t *testing.T) {
+	cfg := erc20gen.NewConfig("X", "X")
+	cfg.DeployStyle = erc20gen.DeployStyleHardhatDeploy
+
+	paths, err := erc20gen.LayoutPaths(cfg, erc20gen.LayoutFoundry)
+	require.NoError(t, err)
+	assert.Equal(t, "deploy/deploy_X.js", paths.Deploy)
+
+	paths, err = erc20gen.LayoutPaths(cfg, erc20gen.LayoutFlat)
+	require.NoError(t, err)
+	assert.Equal(t, "deploy_X.js", paths.Deploy)
+}
+
+func TestLayoutPaths_InvalidLayout(t *testing.T) {
+	_, err := erc20gen.LayoutPaths(erc20gen.NewConfig("X", "X"), "truffle")
+	require.Error(t, err)
+}
+
+func TestGenerateContext_CanceledStopsEarly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	files, err := erc20gen.GenerateContext(ctx, multiFeatureConfig(), erc20gen.Options{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, files)
+}
+
+func TestWriteContract_MatchesGenerate(t *testing.T) {
+	for _, eol := range []erc20gen.LineEnding{"", erc20gen.LineEndingCRLF} {
+		cfg := multiFeatureConfig()
+		cfg.EOL = eol
+		files, err := erc20gen.Generate(cfg, erc20gen.Options{Seed: 1})
+		require.NoError(t, err)
+
+		var contract, deploy, test bytes.Buffer
+		require.NoError(t, erc20gen.WriteContract(context.Background(), &contract, cfg, erc20gen.Options{Seed: 1}))
+		require.NoError(t, erc20gen.WriteDeployScript(context.Background(), &deploy, cfg, erc20gen.Options{Seed: 1}))
+		require.NoError(t, erc20gen.WriteTest(context.Background(), &test, cfg, erc20gen.Options{Seed: 1}))
+		paths, err := erc20gen.LayoutPaths(cfg, erc20gen.LayoutHardhat)
+		require.NoError(t, err)
+		require.NotEmpty(t, files[paths.Contract])
+		assert.Equal(t, files[paths.Contract], contract.String(), eol)
+		assert.Equal(t, files[paths.Deploy], deploy.String(), eol)
+		assert.Equal(t, files[paths.Test], test.String(), eol)
+	}
+}
+
+func TestWriteContract_ReturnsValidationError(t *testing.T) {
+	var buf bytes.Buffer
+	err := erc20gen.WriteContract(context.Background(), &buf, erc20gen.NewConfig("", "LIB"), erc20gen.Options{})
+	var verr *erc20gen.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Zero(t, buf.Len())
+}